@@ -0,0 +1,144 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wahlandcase/attuned.prmanager/internal/models"
+	"github.com/wahlandcase/attuned.prmanager/internal/release"
+
+	"github.com/spf13/cobra"
+)
+
+func newBatchCmd() *cobra.Command {
+	var (
+		prTypeSlug  string
+		title       string
+		groups      []string
+		concurrency int
+		jsonOutput  bool
+		changelog   bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "batch",
+		Short: "Create or update release PRs across all discovered repos without the TUI",
+		Example: `  attpr batch --type dev-staging
+  attpr batch --type staging-main --title "Sprint 42" --group backend --json`,
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, client, err := loadConfigAndClient()
+			if err != nil {
+				return err
+			}
+
+			prType, err := cfg.ReleaseFlow().FindPrType(prTypeSlug)
+			if err != nil {
+				return err
+			}
+			if title == "" {
+				if prType.Final {
+					return fmt.Errorf("--title is required for %s releases", prType.Slug())
+				}
+				title = prType.DefaultTitle("main")
+			}
+
+			if err := checkGroups(cfg.RepoGroups(), groups); err != nil {
+				return err
+			}
+
+			repos, err := release.FindRepos(cfg)
+			if err != nil {
+				return fmt.Errorf("failed to find repos: %w", err)
+			}
+			repos = release.FilterGroups(repos, groups)
+
+			if concurrency <= 0 {
+				concurrency = cfg.Concurrency.BatchOrDefault()
+			}
+			var progressMu sync.Mutex
+			results := release.CreatePRs(client, repos, prType, release.CreateOptions{
+				Title:       title,
+				Tracker:     cfg.Tracker(),
+				Sprint:      cfg.Sprint.Number(time.Now()),
+				Changelog:   changelog || !cmd.Flags().Changed("changelog") && cfg.Changelog.Enabled,
+				TicketRegex: cfg.TicketRegex(),
+				SkipFetch:   dryRun,
+			}, concurrency, func(repo models.RepoInfo, step string) {
+				progressMu.Lock()
+				defer progressMu.Unlock()
+				fmt.Fprintf(cmd.ErrOrStderr(), "%s: %s\n", repo.DisplayName, step)
+			})
+
+			if jsonOutput {
+				enc := json.NewEncoder(cmd.OutOrStdout())
+				enc.SetIndent("", "  ")
+				if err := enc.Encode(results); err != nil {
+					return err
+				}
+			} else {
+				printBatchResults(cmd, results)
+			}
+
+			failed := 0
+			for _, r := range results {
+				if models.IsStatusFailed(r.Status) {
+					failed++
+				}
+			}
+			if failed > 0 {
+				return fmt.Errorf("%d of %d repos failed", failed, len(results))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&prTypeSlug, "type", "t", "", `Promotion to create, as "<head>-<base>" stages (e.g. dev-staging)`)
+	cmd.Flags().StringVar(&title, "title", "", "PR title (defaults to \"<head> → <base>\"; required for production releases)")
+	cmd.Flags().StringSliceVarP(&groups, "group", "g", nil, "Only include repos in this group (repeatable)")
+	cmd.Flags().IntVarP(&concurrency, "concurrency", "j", 0, "Repos to process at once (defaults to concurrency.batch)")
+	cmd.Flags().BoolVar(&changelog, "changelog", false, "Add a conventional commit changelog to PR bodies (defaults to changelog.enabled)")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Print results as JSON")
+	cmd.MarkFlagRequired("type")
+
+	return cmd
+}
+
+// checkGroups returns an error if any requested group isn't configured
+func checkGroups(configured []models.RepoGroup, requested []string) error {
+	var names []string
+	for _, g := range configured {
+		names = append(names, g.Name)
+	}
+	for _, r := range requested {
+		found := false
+		for _, n := range names {
+			if n == r {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("unknown group %q (expected one of: %s)", r, strings.Join(names, ", "))
+		}
+	}
+	return nil
+}
+
+// printBatchResults prints one line per repo, failures included
+func printBatchResults(cmd *cobra.Command, results []models.BatchResult) {
+	out := cmd.OutOrStdout()
+	for _, r := range results {
+		switch {
+		case models.IsStatusSuccess(r.Status):
+			fmt.Fprintf(out, "%-8s %s %s (%d tickets)\n", models.StatusName(r.Status), r.Repo.DisplayName, *r.PrURL, len(r.Tickets))
+		default:
+			fmt.Fprintf(out, "%-8s %s: %s\n", models.StatusName(r.Status), r.Repo.DisplayName, models.GetStatusReason(r.Status))
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/wahlandcase/attuned.prmanager/internal/git"
+	"github.com/wahlandcase/attuned.prmanager/internal/models"
+	"github.com/wahlandcase/attuned.prmanager/internal/release"
+
+	"github.com/spf13/cobra"
+)
+
+func newCreateCmd() *cobra.Command {
+	var (
+		prTypeSlug string
+		title      string
+		repoPath   string
+		changelog  bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create or update a release PR for one repo without the TUI",
+		Example: `  attpr create --type dev-staging
+  attpr create --type staging-main --title "Sprint 42" --repo ~/code/api`,
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, client, err := loadConfigAndClient()
+			if err != nil {
+				return err
+			}
+
+			repo, err := git.GetRepoInfoFromDir(repoPath)
+			if errors.Is(err, os.ErrNotExist) {
+				return fmt.Errorf("not in a git repository: %s: %w", repoPath, err)
+			} else if err != nil {
+				return fmt.Errorf("failed to read repository %s: %w", repoPath, err)
+			}
+			if _, err := cfg.ResolveRepo(repo); err != nil {
+				return err
+			}
+
+			prType, err := repo.Flow.FindPrType(prTypeSlug)
+			if err != nil {
+				return err
+			}
+			if title == "" {
+				if prType.Final {
+					return fmt.Errorf("--title is required for %s releases", prType.Slug())
+				}
+				title = prType.DefaultTitle(repo.MainBranch)
+			}
+
+			result := release.CreatePR(client, *repo, prType, release.CreateOptions{
+				Title:       title,
+				Tracker:     cfg.Tracker(),
+				Sprint:      cfg.Sprint.Number(time.Now()),
+				Changelog:   changelog || !cmd.Flags().Changed("changelog") && cfg.Changelog.Enabled,
+				TicketRegex: cfg.TicketRegex(),
+				SkipFetch:   dryRun,
+				Progress: func(step string) {
+					fmt.Fprintln(cmd.ErrOrStderr(), step)
+				},
+			})
+
+			return printCreateResult(cmd, result)
+		},
+	}
+
+	cmd.Flags().StringVarP(&prTypeSlug, "type", "t", "", `Promotion to create, as "<head>-<base>" stages (e.g. dev-staging)`)
+	cmd.Flags().StringVar(&title, "title", "", "PR title (defaults to \"<head> → <base>\"; required for production releases)")
+	cmd.Flags().StringVar(&repoPath, "repo", ".", "Path to the repository")
+	cmd.Flags().BoolVar(&changelog, "changelog", false, "Add a conventional commit changelog to the PR body (defaults to changelog.enabled)")
+	cmd.MarkFlagRequired("type")
+
+	return cmd
+}
+
+// printCreateResult prints a single repo result, returning an error if it failed
+func printCreateResult(cmd *cobra.Command, result models.BatchResult) error {
+	out := cmd.OutOrStdout()
+	switch {
+	case models.IsStatusCreated(result.Status):
+		fmt.Fprintf(out, "Created %s (%d tickets)\n", *result.PrURL, len(result.Tickets))
+	case models.IsStatusUpdated(result.Status):
+		fmt.Fprintf(out, "Updated %s (%d tickets)\n", *result.PrURL, len(result.Tickets))
+	case models.IsStatusSkipped(result.Status):
+		fmt.Fprintf(out, "Skipped %s: %s\n", result.Repo.DisplayName, models.GetStatusReason(result.Status))
+	default:
+		return fmt.Errorf("%s: %s", result.Repo.DisplayName, models.GetStatusReason(result.Status))
+	}
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/wahlandcase/attuned.prmanager/internal/app"
 	"github.com/wahlandcase/attuned.prmanager/internal/config"
+	"github.com/wahlandcase/attuned.prmanager/internal/github"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
@@ -29,10 +30,15 @@ func main() {
 		RunE:  run,
 	}
 
-	rootCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Simulate operations without making changes")
+	rootCmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "Simulate operations without making changes")
 	rootCmd.Flags().BoolVar(&testUpdate, "test-update", false, "Show update prompt for testing")
 	rootCmd.Flags().MarkHidden("test-update")
 
+	rootCmd.AddCommand(newCreateCmd())
+	rootCmd.AddCommand(newBatchCmd())
+	rootCmd.AddCommand(newStatusCmd())
+	rootCmd.AddCommand(newMergeCmd())
+
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
@@ -40,12 +46,12 @@ func main() {
 }
 
 func run(cmd *cobra.Command, args []string) error {
-	cfg, err := config.Load()
+	cfg, client, err := loadConfigAndClient()
 	if err != nil {
-		return fmt.Errorf("failed to load config: %w", err)
+		return err
 	}
 
-	model := app.New(cfg, dryRun, testUpdate, Version)
+	model := app.New(cfg, client, dryRun, testUpdate, Version)
 	p := tea.NewProgram(model, tea.WithAltScreen())
 
 	if _, err := p.Run(); err != nil {
@@ -54,3 +60,33 @@ func run(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// loadConfigAndClient loads the config and builds the GitHub client it selects
+func loadConfigAndClient() (*config.Config, github.Client, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	client, err := newGitHubClient(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cfg, client, nil
+}
+
+// newGitHubClient builds the GitHub backend selected by config (fake client in dry run mode)
+func newGitHubClient(cfg *config.Config) (github.Client, error) {
+	if dryRun {
+		return app.NewDryRunClient(), nil
+	}
+
+	switch cfg.GitHub.Backend {
+	case "", config.BackendGh:
+		return github.NewGhClient(), nil
+	case config.BackendAPI:
+		return github.NewAPIClient(cfg.GitHub.APIURL, github.ResolveToken(cfg.GitHub.APIURL)), nil
+	default:
+		return nil, fmt.Errorf("invalid github.backend %q (expected %q or %q)", cfg.GitHub.Backend, config.BackendGh, config.BackendAPI)
+	}
+}
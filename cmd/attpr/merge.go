@@ -0,0 +1,306 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/wahlandcase/attuned.prmanager/internal/app"
+	"github.com/wahlandcase/attuned.prmanager/internal/models"
+	"github.com/wahlandcase/attuned.prmanager/internal/release"
+
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+)
+
+func newMergeCmd() *cobra.Command {
+	var (
+		prTypeSlug    string
+		repoNames     []string
+		all           bool
+		groups        []string
+		requireChecks bool
+		auto          bool
+		yes           bool
+		jsonOutput    bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "merge",
+		Short: "Merge open release PRs without the TUI",
+		Example: `  attpr merge --type dev-staging --repos web,api
+  attpr merge --type staging-main --all --require-checks --yes --json
+  attpr merge --type dev-staging --all --auto --yes`,
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if all == (len(repoNames) > 0) {
+				return fmt.Errorf("specify exactly one of --repos or --all")
+			}
+			if !yes && !stdinIsTerminal() {
+				return fmt.Errorf("refusing to merge without --yes when stdin is not a terminal")
+			}
+
+			cfg, client, err := loadConfigAndClient()
+			if err != nil {
+				return err
+			}
+
+			prType, err := cfg.ReleaseFlow().FindPrType(prTypeSlug)
+			if err != nil {
+				return err
+			}
+			if err := checkGroups(cfg.RepoGroups(), groups); err != nil {
+				return err
+			}
+
+			// Dry run uses fake repos known to the fake client
+			repos := app.DryRunRepos(cfg)
+			if !dryRun {
+				repos, err = release.FindRepos(cfg)
+				if err != nil {
+					return fmt.Errorf("failed to find repos: %w", err)
+				}
+			}
+			repos = release.FilterGroups(repos, groups)
+			if !all {
+				if repos, err = selectRepos(repos, repoNames); err != nil {
+					return err
+				}
+			}
+
+			entries, err := release.OpenMergeEntries(client, repos)
+			if err != nil {
+				return fmt.Errorf("failed to fetch open PRs: %w", err)
+			}
+
+			// Keep PRs for the requested promotion (mapped onto each repo's flow)
+			errOut := cmd.ErrOrStderr()
+			var toMerge []models.MergePrEntry
+			for _, e := range entries {
+				repoType, ok := e.Repo.Flow.Resolve(prType)
+				if !ok || repoType.Head != e.PrType.Head || repoType.Base != e.PrType.Base {
+					continue
+				}
+				if requireChecks && e.ChecksState != "SUCCESS" {
+					fmt.Fprintf(errOut, "Skipping %s #%d: checks not passing (%s)\n", e.Repo.DisplayName, e.PrNumber, checksLabel(e.ChecksState))
+					continue
+				}
+				toMerge = append(toMerge, e)
+			}
+
+			if len(toMerge) == 0 {
+				fmt.Fprintf(errOut, "No open %s PRs to merge\n", prType.Display("main"))
+				if jsonOutput {
+					fmt.Fprintln(cmd.OutOrStdout(), "[]")
+				}
+				return nil
+			}
+
+			// Upstream repos (merge_after) go first
+			order, err := release.MergeOrder(toMerge)
+			if err != nil {
+				return err
+			}
+			deps := release.MergeDependencies(toMerge)
+
+			if !yes {
+				for _, i := range order {
+					e := toMerge[i]
+					fmt.Fprintf(errOut, "  %s #%d %s (%s)\n", e.Repo.DisplayName, e.PrNumber, e.PrTitle, e.MergeMethod().Describe())
+				}
+				question := fmt.Sprintf("Merge %d PRs?", len(toMerge))
+				if auto {
+					question = fmt.Sprintf("Enable auto-merge on %d PRs?", len(toMerge))
+				}
+				if !confirm(cmd.InOrStdin(), errOut, question) {
+					return fmt.Errorf("aborted")
+				}
+			}
+
+			merged := make(map[int]bool)        // Entry index -> merged
+			mergedAt := make(map[int]time.Time) // Entry index -> when it merged
+			deployErrs := make(map[int]error)   // Entry index -> deploy result, once waited on
+
+			// blocker waits for an entry's dependencies to merge and deploy, returning why it can't merge
+			blocker := func(i int) string {
+				for _, d := range deps[i] {
+					up := toMerge[d]
+					if !merged[d] {
+						return up.Repo.DisplayName + " didn't merge"
+					}
+					// Auto-merge leaves timing to GitHub, so there's no merge to wait for a deploy after
+					if auto || up.Repo.Settings.DeployWorkflow == "" {
+						continue
+					}
+					err, waited := deployErrs[d]
+					if !waited {
+						branch := up.PrType.BaseBranch(up.Repo.MainBranch)
+						fmt.Fprintf(errOut, "Waiting for %s %s on %s...\n", up.Repo.DisplayName, up.Repo.Settings.DeployWorkflow, branch)
+						err = release.WaitForDeploy(client, up.Repo, branch, mergedAt[d],
+							cfg.MergeWait.TimeoutOrDefault(), cfg.MergeWait.PollIntervalOrDefault())
+						deployErrs[d] = err
+					}
+					if err != nil {
+						return err.Error()
+					}
+				}
+				return ""
+			}
+
+			results := make([]models.MergeResult, 0, len(toMerge))
+			for _, i := range order {
+				e := toMerge[i]
+				if reason := blocker(i); reason != "" {
+					fmt.Fprintf(errOut, "Skipping %s #%d: %s\n", e.Repo.DisplayName, e.PrNumber, reason)
+					results = append(results, skippedMergeResult(e, reason))
+					continue
+				}
+				if auto {
+					fmt.Fprintf(errOut, "Enabling auto-merge for %s #%d (%s)...\n", e.Repo.DisplayName, e.PrNumber, e.MergeMethod().Describe())
+					result := release.EnableAutoMerge(client, e)
+					merged[i] = result.Success
+					results = append(results, result)
+					continue
+				}
+				fmt.Fprintf(errOut, "Merging %s #%d (%s)...\n", e.Repo.DisplayName, e.PrNumber, e.MergeMethod().Describe())
+				result := release.MergePR(client, e)
+				merged[i], mergedAt[i] = result.Success, time.Now()
+				results = append(results, result)
+			}
+
+			if jsonOutput {
+				enc := json.NewEncoder(cmd.OutOrStdout())
+				enc.SetIndent("", "  ")
+				if err := enc.Encode(results); err != nil {
+					return err
+				}
+			} else if err := printMergeResults(cmd.OutOrStdout(), results); err != nil {
+				return err
+			}
+
+			failed := 0
+			for _, r := range results {
+				if !r.Success {
+					failed++
+				}
+			}
+			if failed > 0 {
+				return fmt.Errorf("%d of %d merges failed", failed, len(results))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&prTypeSlug, "type", "t", "", `Promotion to merge, as "<head>-<base>" stages (e.g. dev-staging)`)
+	cmd.Flags().StringSliceVar(&repoNames, "repos", nil, `Repos to merge, by display name or last segment (e.g. "backend/api" or "api")`)
+	cmd.Flags().BoolVar(&all, "all", false, "Merge in every discovered repo")
+	cmd.Flags().StringSliceVarP(&groups, "group", "g", nil, "Only include repos in this group (repeatable)")
+	cmd.Flags().BoolVar(&requireChecks, "require-checks", false, "Skip PRs whose checks haven't all passed")
+	cmd.Flags().BoolVar(&auto, "auto", false, "Enable GitHub auto-merge instead of merging now")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Merge without asking for confirmation")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Print results as JSON")
+	cmd.MarkFlagRequired("type")
+	cmd.MarkFlagsMutuallyExclusive("auto", "require-checks")
+
+	return cmd
+}
+
+// skippedMergeResult is the result for an entry that wasn't attempted
+func skippedMergeResult(e models.MergePrEntry, reason string) models.MergeResult {
+	reason = "skipped: " + reason
+	return models.MergeResult{
+		RepoName: e.Repo.DisplayName,
+		PrNumber: e.PrNumber,
+		PrTitle:  e.PrTitle,
+		PrType:   e.PrType,
+		URL:      e.URL,
+		Error:    &reason,
+	}
+}
+
+// selectRepos keeps the named repos, matching display name or else last segment. A last
+// segment shared by several repos is an error; naming a repo twice selects it once.
+func selectRepos(repos []models.RepoInfo, names []string) ([]models.RepoInfo, error) {
+	var selected []models.RepoInfo
+	seen := make(map[string]bool)
+	for _, name := range names {
+		match, err := findRepo(repos, name)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[match.Path] {
+			seen[match.Path] = true
+			selected = append(selected, match)
+		}
+	}
+	return selected, nil
+}
+
+// findRepo finds the repo with the given display name, or the only one with that last segment
+func findRepo(repos []models.RepoInfo, name string) (models.RepoInfo, error) {
+	var candidates []models.RepoInfo
+	for _, r := range repos {
+		if r.DisplayName == name {
+			return r, nil
+		}
+		if r.ShortName() == name {
+			candidates = append(candidates, r)
+		}
+	}
+
+	switch len(candidates) {
+	case 0:
+		return models.RepoInfo{}, fmt.Errorf("unknown repo %q", name)
+	case 1:
+		return candidates[0], nil
+	}
+	var names []string
+	for _, r := range candidates {
+		names = append(names, r.DisplayName)
+	}
+	return models.RepoInfo{}, fmt.Errorf("ambiguous repo %q matches %s; use the full name", name, strings.Join(names, ", "))
+}
+
+// checksLabel describes a checks state for messages
+func checksLabel(state string) string {
+	if state == "" {
+		return "no checks"
+	}
+	return strings.ToLower(state)
+}
+
+// stdinIsTerminal reports whether stdin is interactive
+func stdinIsTerminal() bool {
+	return isatty.IsTerminal(os.Stdin.Fd()) || isatty.IsCygwinTerminal(os.Stdin.Fd())
+}
+
+// confirm asks a yes/no question, defaulting to no
+func confirm(in io.Reader, out io.Writer, question string) bool {
+	fmt.Fprintf(out, "%s [y/N] ", question)
+	answer, _ := bufio.NewReader(in).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+
+// printMergeResults prints one row per merged PR
+func printMergeResults(out io.Writer, results []models.MergeResult) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "REPO\tPR\tRESULT")
+	for _, r := range results {
+		status := "merged"
+		if !r.Success {
+			status = "failed: " + *r.Error
+		} else if r.AutoMerge {
+			status = "auto-merge enabled"
+		}
+		fmt.Fprintf(w, "%s\t#%d\t%s\n", r.RepoName, r.PrNumber, status)
+	}
+	return w.Flush()
+}
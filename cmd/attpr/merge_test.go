@@ -0,0 +1,52 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/wahlandcase/attuned.prmanager/internal/models"
+)
+
+func TestSelectRepos(t *testing.T) {
+	repos := []models.RepoInfo{
+		{Path: "/src/frontend/web", DisplayName: "frontend/web"},
+		{Path: "/src/admin/web", DisplayName: "admin/web"},
+		{Path: "/src/api", DisplayName: "api"},
+		{Path: "/src/services/worker", DisplayName: "services/worker"},
+	}
+
+	tests := []struct {
+		name  string
+		names []string
+		want  []string
+		err   string
+	}{
+		{name: "display name", names: []string{"frontend/web"}, want: []string{"/src/frontend/web"}},
+		{name: "unique last segment", names: []string{"worker", "api"}, want: []string{"/src/services/worker", "/src/api"}},
+		{name: "duplicates selected once", names: []string{"api", "api", "services/worker", "worker"}, want: []string{"/src/api", "/src/services/worker"}},
+		{name: "ambiguous last segment", names: []string{"web"}, err: `ambiguous repo "web" matches frontend/web, admin/web`},
+		{name: "unknown", names: []string{"mobile"}, err: `unknown repo "mobile"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selected, err := selectRepos(repos, tt.names)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("error = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var got []string
+			for _, r := range selected {
+				got = append(got, r.Path)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("selected %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/wahlandcase/attuned.prmanager/internal/release"
+
+	"github.com/spf13/cobra"
+)
+
+// Output formats for attpr status
+const (
+	formatTable    = "table"
+	formatJSON     = "json"
+	formatMarkdown = "markdown"
+)
+
+// maxTableTickets caps the tickets listed per row in table output
+const maxTableTickets = 5
+
+func newStatusCmd() *cobra.Command {
+	var (
+		format  string
+		groups  []string
+		noFetch bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show unreleased commits, tickets and open release PRs across all repos",
+		Example: `  attpr status
+  attpr status --group backend --format markdown
+  attpr status --format json --no-fetch`,
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			switch format {
+			case formatTable, formatJSON, formatMarkdown:
+			default:
+				return fmt.Errorf("invalid --format %q (expected %s, %s or %s)", format, formatTable, formatJSON, formatMarkdown)
+			}
+
+			cfg, client, err := loadConfigAndClient()
+			if err != nil {
+				return err
+			}
+			if err := checkGroups(cfg.RepoGroups(), groups); err != nil {
+				return err
+			}
+
+			repos, err := release.FindRepos(cfg)
+			if err != nil {
+				return fmt.Errorf("failed to find repos: %w", err)
+			}
+			repos = release.FilterGroups(repos, groups)
+
+			statuses, err := release.Status(client, repos, release.StatusOptions{
+				TicketRegex: cfg.TicketRegex(),
+				NoFetch:     noFetch,
+				Progress: func(repo string) {
+					fmt.Fprintf(cmd.ErrOrStderr(), "%s...\n", repo)
+				},
+			})
+			if err != nil {
+				return fmt.Errorf("failed to fetch open PRs: %w", err)
+			}
+
+			out := cmd.OutOrStdout()
+			switch format {
+			case formatJSON:
+				enc := json.NewEncoder(out)
+				enc.SetIndent("", "  ")
+				return enc.Encode(statuses)
+			case formatMarkdown:
+				printStatusMarkdown(out, statuses)
+			default:
+				return printStatusTable(out, statuses)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&format, "format", "f", formatTable, "Output format: table, json or markdown")
+	cmd.Flags().StringSliceVarP(&groups, "group", "g", nil, "Only include repos in this group (repeatable)")
+	cmd.Flags().BoolVar(&noFetch, "no-fetch", false, "Skip fetching from origin (use local remote-tracking branches)")
+
+	return cmd
+}
+
+// printStatusTable prints one row per promotion, aligned for the terminal
+func printStatusTable(out io.Writer, statuses []release.RepoStatus) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "REPO\tPROMOTION\tCOMMITS\tTICKETS\tPR")
+	for _, s := range statuses {
+		if s.Error != "" {
+			fmt.Fprintf(w, "%s\t-\t-\t-\terror: %s\n", s.Repo.DisplayName, s.Error)
+			continue
+		}
+		for _, p := range s.Promotions {
+			commits := fmt.Sprintf("%d", len(p.Commits))
+			if p.Error != "" {
+				commits = "error: " + p.Error
+			}
+			pr := "-"
+			if p.PR != nil {
+				pr = fmt.Sprintf("#%d", p.PR.Number)
+			}
+			fmt.Fprintf(w, "%s\t%s → %s\t%s\t%s\t%s\n", s.Repo.DisplayName, p.Head, p.Base, commits, ticketSummary(p.Tickets, maxTableTickets), pr)
+		}
+	}
+	return w.Flush()
+}
+
+// printStatusMarkdown prints a Markdown table (for PR descriptions, Slack, release notes)
+func printStatusMarkdown(out io.Writer, statuses []release.RepoStatus) {
+	fmt.Fprintln(out, "| Repo | Promotion | Commits | Tickets | PR |")
+	fmt.Fprintln(out, "|------|-----------|---------|---------|----|")
+	for _, s := range statuses {
+		if s.Error != "" {
+			fmt.Fprintf(out, "| %s | | | | ⚠️ %s |\n", s.Repo.DisplayName, markdownEscape(s.Error))
+			continue
+		}
+		for _, p := range s.Promotions {
+			commits := fmt.Sprintf("%d", len(p.Commits))
+			if p.Error != "" {
+				commits = "⚠️ " + markdownEscape(p.Error)
+			}
+			pr := ""
+			if p.PR != nil {
+				pr = fmt.Sprintf("[#%d](%s)", p.PR.Number, p.PR.URL)
+			}
+			fmt.Fprintf(out, "| %s | %s → %s | %s | %s | %s |\n", s.Repo.DisplayName, p.Head, p.Base, commits, strings.Join(p.Tickets, ", "), pr)
+		}
+	}
+}
+
+// ticketSummary joins up to max tickets, noting how many more there are
+func ticketSummary(tickets []string, max int) string {
+	if len(tickets) == 0 {
+		return "-"
+	}
+	if len(tickets) <= max {
+		return strings.Join(tickets, ", ")
+	}
+	return fmt.Sprintf("%s (+%d)", strings.Join(tickets[:max], ", "), len(tickets)-max)
+}
+
+// markdownEscape keeps text from breaking a Markdown table row
+func markdownEscape(s string) string {
+	s = strings.ReplaceAll(s, "|", "\\|")
+	return strings.ReplaceAll(s, "\n", " ")
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/wahlandcase/attuned.prmanager/internal/config"
+	"github.com/wahlandcase/attuned.prmanager/internal/github"
 	"github.com/wahlandcase/attuned.prmanager/internal/models"
 	"github.com/wahlandcase/attuned.prmanager/internal/ui"
 	"github.com/wahlandcase/attuned.prmanager/internal/update"
@@ -26,7 +27,7 @@ type ConfettiParticle struct {
 type sessionPR struct {
 	repoName  string
 	url       string
-	prType    string // "dev→staging" or "staging→main"
+	prType    string // Promotion label, e.g. "staging→main"
 	createdAt time.Time
 }
 
@@ -34,13 +35,14 @@ type sessionPR struct {
 type Model struct {
 	// Configuration
 	config     *config.Config
+	gh         github.Client // GitHub backend (fake client in dry run mode)
 	dryRun     bool
 	testUpdate bool
 
 	// Navigation
-	screen       Screen
-	menuIndex    int
-	shouldQuit   bool
+	screen     Screen
+	menuIndex  int
+	shouldQuit bool
 
 	// Mode
 	mode *AppMode
@@ -53,6 +55,8 @@ type Model struct {
 	prTitle    string
 	prURL      string
 	existingPR *models.GhPr // Non-nil if PR already exists (will update)
+	// Add a conventional commit changelog to PR bodies (single and batch; Tab on commit review)
+	prChangelog bool
 
 	// Batch mode state
 	batchRepos            []models.RepoInfo
@@ -62,29 +66,36 @@ type Model struct {
 	batchFetchPending     int                        // Number of repos still fetching
 	batchSelected         []bool
 	batchResults          []models.BatchResult
-	batchCurrent          int
-	batchCurrentRepo      string // Name of repo currently being processed
+	batchQueue            []int          // Selected repo indices not started yet
+	batchInFlight         map[int]string // Repo index -> current step (e.g., "Fetching branches...") while processing
 	batchTotal            int
 	batchFilter           string
-	batchColumn           int // 0=Frontend, 1=Backend
-	batchFEIndex          int
-	batchBEIndex          int
-	batchExistingPRs      int                // Count of repos with existing PRs (will update)
-	batchReposWithCommits int                // Count of repos that have commits to merge
-	batchConfirmScroll    int                // Scroll offset for batch confirmation right column
-	batchProgressChan     chan string        // Channel for real-time progress updates
-	batchCurrentStep      string             // Current step being executed (e.g., "Fetching branches...")
+	batchGroups           []string              // Repo groups shown as columns, in config order
+	batchColumn           int                   // Index into batchGroups
+	batchColumnIndex      []int                 // Selected row per column
+	batchExistingPRs      int                   // Count of repos with existing PRs (will update)
+	batchReposWithCommits int                   // Count of repos that have commits to merge
+	batchConfirmScroll    int                   // Scroll offset for batch confirmation right column
+	batchProgressChan     chan batchProgressMsg // Channel for real-time progress updates
 
 	// Open PRs / Merge state
-	openPRs       []OpenPREntry
-	mergePRs      []models.MergePrEntry
-	mergeSelected  []bool
-	mergeColumn    int // 0=dev->staging, 1=staging->main
-	mergeDevIndex  int
-	mergeMainIndex int
-	mergeResults   []models.MergeResult
-	mergeCurrent   int
-	mergeTotal     int
+	openPRs            []OpenPREntry
+	mergePRs           []models.MergePrEntry
+	mergeSelected      []bool
+	mergeColumn        int   // Promotion index in the global flow (0=first, e.g. dev->staging)
+	mergeColumnIndex   []int // Selected row per column
+	mergeOverride      bool  // Allow selecting PRs with conflicts, failing checks or missing reviews
+	mergeAuto          bool  // Enable GitHub auto-merge on selected PRs instead of merging now
+	mergeWait          bool  // Wait for each PR's checks to pass, then merge it
+	mergeWaitEntries   []mergeWaitEntry
+	mergeWaitStarted   time.Time
+	mergeResults       []models.MergeResult
+	mergeQueue         []int                // Selected PR indices in merge_after order
+	mergeDeps          map[int][]int        // PR index -> PR indices that must merge first
+	mergeOutcomes      map[int]bool         // PR index -> merged (false if it failed or was skipped)
+	mergeDeploys       map[int]*mergeDeploy // PR index -> deploy that dependents wait on
+	mergeDeployWaiting string               // What the next queued PR is waiting on, if anything
+	mergeCurrent       int                  // Position in mergeQueue
 
 	// UI state
 	confirmSelection int // 0=Yes, 1=No
@@ -110,16 +121,19 @@ type Model struct {
 	historyIndex int
 
 	// Pull all state
-	pullBranch     string // "dev", "staging", or "main"
-	pullRepos      []models.RepoInfo
-	pullResults    []models.PullResult
-	pullCurrentIdx int
+	pullStage     int // Index into the global flow's stages
+	pullRepos     []models.RepoInfo
+	pullResults   map[int]models.PullResult // Repo index -> result, once finished
+	pullQueue     []int                     // Repo indices not started yet
+	pullInFlight  map[int]bool              // Repo indices being pulled
+	pullAutoStash bool                      // Stash local changes around the pull instead of skipping dirty repos
+	pullMode      string                    // config.PullMode*: whether pulled branches stay checked out
 
 	// GitHub Actions state
-	actionsEntries     []actionsEntry
-	actionsIndex       int // flat index into filtered entries
-	actionsLoading     bool
-	actionsLastRefresh time.Time
+	actionsEntries      []actionsEntry
+	actionsIndex        int // flat index into filtered entries
+	actionsLoading      bool
+	actionsLastRefresh  time.Time
 	actionsFilter       string
 	actionsFilterActive bool
 
@@ -257,6 +271,46 @@ func (m *Model) copyWithFeedback(text, successMsg string) {
 	}
 }
 
+// prTypeOptions returns the promotions offered on the PR type screen: the current repo's
+// flow in single mode, the global flow in batch mode
+func (m Model) prTypeOptions() []models.PrType {
+	if m.repoInfo != nil && (m.mode == nil || *m.mode == ModeSingle) {
+		return m.repoInfo.Flow.PrTypes()
+	}
+	return m.config.ReleaseFlow().PrTypes()
+}
+
+// pullBranch returns the name of the global flow stage being pulled
+func (m Model) pullBranch() string {
+	stages := m.config.ReleaseFlow().Stages
+	if m.pullStage < len(stages) {
+		return stages[m.pullStage]
+	}
+	return ""
+}
+
+// renderPR returns the PR title and body for the current repo with its PR templates applied
+func (m Model) renderPR() (string, string, error) {
+	if m.repoInfo == nil || m.prType == nil {
+		return m.prTitle, github.GeneratePRBody(m.tickets, m.config.Tracker()), nil
+	}
+	return github.RenderPR(*m.repoInfo, *m.prType, m.prTitle, m.commits, github.BodyOptions{
+		Tracker:   m.config.Tracker(),
+		Sprint:    m.config.Sprint.Number(time.Now()),
+		Changelog: m.prChangelog,
+	})
+}
+
+// groupColor returns the color of a repo group, by its position in the configured groups
+func (m Model) groupColor(group string) lipgloss.Color {
+	for i, g := range m.config.RepoGroups() {
+		if g.Name == group {
+			return ui.GroupColor(i)
+		}
+	}
+	return ui.ColorWhite
+}
+
 // mainBranch returns the main branch name for the current repo, defaulting to "main"
 func (m Model) mainBranch() string {
 	if m.repoInfo != nil {
@@ -266,9 +320,10 @@ func (m Model) mainBranch() string {
 }
 
 // New creates a new application model
-func New(cfg *config.Config, dryRun, testUpdate bool, version string) Model {
+func New(cfg *config.Config, client github.Client, dryRun, testUpdate bool, version string) Model {
 	return Model{
 		config:     cfg,
+		gh:         client,
 		dryRun:     dryRun,
 		testUpdate: testUpdate,
 		version:    version,
@@ -277,6 +332,8 @@ func New(cfg *config.Config, dryRun, testUpdate bool, version string) Model {
 		width:      80,
 		height:     24,
 		sessionPRs: loadHistory(),
+
+		prChangelog: cfg.Changelog.Enabled,
 	}
 }
 
@@ -287,7 +344,7 @@ func (m Model) Init() tea.Cmd {
 		tickCmd(),
 	}
 	if !m.dryRun {
-		cmds = append(cmds, authCheckCmd())
+		cmds = append(cmds, authCheckCmd(m.gh))
 		// Check for updates if enabled and 24h since last check
 		if m.config.ShouldCheckForUpdate() {
 			cmds = append(cmds, checkUpdateCmd(m.version, m.config.Update.Repo))
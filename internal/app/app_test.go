@@ -0,0 +1,275 @@
+package app
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wahlandcase/attuned.prmanager/internal/config"
+	"github.com/wahlandcase/attuned.prmanager/internal/github"
+	"github.com/wahlandcase/attuned.prmanager/internal/models"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// newTestModel creates a dry run model with a default config kept out of the real config dir
+func newTestModel(t *testing.T, client github.Client) Model {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("HOME", dir)
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	cfg.MergeWait.PollInterval = "1ms"
+	return New(cfg, client, true, false, "test")
+}
+
+// send passes a message to Update and renders the resulting screen
+func send(t *testing.T, m Model, msg tea.Msg) (Model, tea.Cmd) {
+	t.Helper()
+	next, cmd := m.Update(msg)
+	next.View()
+	return next.(Model), cmd
+}
+
+// press sends a key ("enter", "space", "esc" or runes)
+func press(t *testing.T, m Model, key string) (Model, tea.Cmd) {
+	t.Helper()
+	msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+	switch key {
+	case "enter":
+		msg = tea.KeyMsg{Type: tea.KeyEnter}
+	case "space":
+		msg = tea.KeyMsg{Type: tea.KeySpace}
+	case "esc":
+		msg = tea.KeyMsg{Type: tea.KeyEsc}
+	}
+	return send(t, m, msg)
+}
+
+// run executes a command and any commands it batches, returning the messages produced
+func run(cmd tea.Cmd) []tea.Msg {
+	if cmd == nil {
+		return nil
+	}
+	msg := cmd()
+	if batch, ok := msg.(tea.BatchMsg); ok {
+		var msgs []tea.Msg
+		for _, c := range batch {
+			msgs = append(msgs, run(c)...)
+		}
+		return msgs
+	}
+	if msg == nil {
+		return nil
+	}
+	return []tea.Msg{msg}
+}
+
+// runOne executes a command that must produce exactly one message of type T
+func runOne[T tea.Msg](t *testing.T, cmd tea.Cmd) T {
+	t.Helper()
+	var found []T
+	for _, msg := range run(cmd) {
+		if m, ok := msg.(T); ok {
+			found = append(found, m)
+		}
+	}
+	if len(found) != 1 {
+		var zero T
+		t.Fatalf("got %d %T messages, want 1", len(found), zero)
+	}
+	return found[0]
+}
+
+func TestCreatePR(t *testing.T) {
+	fc := github.NewFakeClient()
+	m := newTestModel(t, fc)
+	repo := DryRunRepos(m.config)[0]
+	prType := repo.Flow.PrTypes()[0]
+	m.repoInfo = &repo
+	m.prType = &prType
+	m.prTitle = "Release web"
+	m.commits = []models.CommitInfo{models.NewCommitInfo("abc1234", "feat: dashboard", []string{"ATT-12"})}
+	m.screen = ScreenConfirmation
+
+	m, cmd := press(t, m, "y")
+	if m.screen != ScreenCreating {
+		t.Fatalf("screen = %v, want ScreenCreating", m.screen)
+	}
+	m, _ = send(t, m, runOne[prCreatedResult](t, cmd))
+	if m.screen != ScreenComplete {
+		t.Fatalf("screen = %v, want ScreenComplete (error: %s)", m.screen, m.errorMessage)
+	}
+
+	pr, err := fc.GetExistingPR(repo.Path, "dev", "staging")
+	if err != nil || pr == nil {
+		t.Fatalf("PR not created: %v", err)
+	}
+	if m.prURL != pr.URL || pr.Title != "Release web" {
+		t.Errorf("prURL = %q, PR = %+v", m.prURL, pr)
+	}
+	if body := fc.PRBody(repo.Path, pr.Number); !strings.Contains(body, "ATT-12") {
+		t.Errorf("body is missing the ticket:\n%s", body)
+	}
+}
+
+func TestCreatePRUpdatesExisting(t *testing.T) {
+	fc := github.NewFakeClient()
+	m := newTestModel(t, fc)
+	repo := DryRunRepos(m.config)[0]
+	prType := repo.Flow.PrTypes()[0]
+	fc.AddPR(repo.Path, "dev", "staging", models.GhPr{Number: 42, URL: "https://github.com/example/web/pull/42", Title: "Old"})
+	m.repoInfo = &repo
+	m.prType = &prType
+	m.prTitle = "New"
+	m.screen = ScreenConfirmation
+
+	m, cmd := press(t, m, "y")
+	m, _ = send(t, m, runOne[prCreatedResult](t, cmd))
+	if m.screen != ScreenComplete || m.prURL != "https://github.com/example/web/pull/42" {
+		t.Fatalf("screen = %v, prURL = %q (error: %s)", m.screen, m.prURL, m.errorMessage)
+	}
+	if pr, _ := fc.GetPR(repo.Path, 42); pr.Title != "New" {
+		t.Errorf("title = %q, want the existing PR updated", pr.Title)
+	}
+}
+
+func TestCreatePRError(t *testing.T) {
+	m := newTestModel(t, github.NewFakeClient())
+	m, _ = send(t, m, prCreatedResult{err: errors.New("create failed")})
+	if m.screen != ScreenError || m.errorMessage != "create failed" {
+		t.Errorf("screen = %v, error = %q", m.screen, m.errorMessage)
+	}
+}
+
+// fetchOpenPRs loads the open PR view from the client
+func fetchOpenPRs(t *testing.T, m Model) Model {
+	t.Helper()
+	m, _ = send(t, m, runOne[openPRsFetchedResult](t, fetchOpenPRsCmd(m.gh, m.config, m.dryRun)))
+	if m.screen != ScreenViewOpenPrs {
+		t.Fatalf("screen = %v, want ScreenViewOpenPrs (error: %s)", m.screen, m.errorMessage)
+	}
+	return m
+}
+
+func TestFetchOpenPRs(t *testing.T) {
+	fc := NewDryRunClient()
+	fc.Latency = 0
+	m := fetchOpenPRs(t, newTestModel(t, fc))
+
+	var got []string
+	for _, pr := range m.mergePRs {
+		got = append(got, pr.Repo.DisplayName+"#"+pr.PrType.Head+"->"+pr.PrType.Base)
+	}
+	want := []string{"frontend/web#dev->staging", "frontend/web#staging->main", "backend/api#dev->staging"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("merge PRs = %v, want %v", got, want)
+	}
+	if len(m.openPRs) != 2 || len(m.mergeSelected) != len(m.mergePRs) {
+		t.Errorf("open PRs = %d, selection = %d", len(m.openPRs), len(m.mergeSelected))
+	}
+	if m.mergePRs[2].Mergeable != "CONFLICTING" || m.mergePRs[0].ChecksState != "SUCCESS" {
+		t.Errorf("merge readiness not carried over: %+v", m.mergePRs)
+	}
+}
+
+func TestMergeFlow(t *testing.T) {
+	fc := NewDryRunClient()
+	fc.Latency = 0
+	m := fetchOpenPRs(t, newTestModel(t, fc))
+
+	// The conflicting api PR can't be selected
+	m, _ = send(t, m, tea.KeyMsg{Type: tea.KeyDown})
+	m, _ = press(t, m, "space")
+	if m.mergeSelectedCount() != 0 || !strings.Contains(m.copyFeedback, "conflicts") {
+		t.Fatalf("selected a blocked PR: %v (%s)", m.mergeSelected, m.copyFeedback)
+	}
+
+	m, _ = send(t, m, tea.KeyMsg{Type: tea.KeyUp})
+	m, _ = press(t, m, "space")
+	if !m.mergeSelected[0] {
+		t.Fatalf("web dev->staging not selected: %v", m.mergeSelected)
+	}
+	m, _ = press(t, m, "enter")
+	if m.screen != ScreenMergeConfirmation {
+		t.Fatalf("screen = %v, want ScreenMergeConfirmation", m.screen)
+	}
+
+	m, cmd := press(t, m, "y")
+	if m.screen != ScreenMerging {
+		t.Fatalf("screen = %v, want ScreenMerging", m.screen)
+	}
+	m, _ = send(t, m, runOne[mergeCompleteResult](t, cmd))
+	if m.screen != ScreenMergeSummary {
+		t.Fatalf("screen = %v, want ScreenMergeSummary", m.screen)
+	}
+	if len(m.mergeResults) != 1 || !m.mergeResults[0].Success || m.mergeResults[0].PrNumber != 123 {
+		t.Fatalf("results = %+v", m.mergeResults)
+	}
+	if pr, _ := fc.GetPR(dryRunRepos[0].Path, 123); pr.State != "merged" {
+		t.Errorf("PR state = %q, want merged", pr.State)
+	}
+}
+
+func TestMergeWaitUsesHeadCommitChecks(t *testing.T) {
+	fc := github.NewFakeClient()
+	web := dryRunRepos[0].Path
+	fc.AddPR(web, "dev", "staging", models.GhPr{Number: 7, Title: "dev → staging",
+		Mergeable: "MERGEABLE", ReviewDecision: "APPROVED", ChecksState: "SUCCESS", HeadSHA: "new"})
+	now := time.Now()
+	fc.AddWorkflowRun(web, models.WorkflowRun{WorkflowName: "CI", HeadBranch: "dev", HeadSHA: "old",
+		Status: "completed", Conclusion: "success", CreatedAt: now.Add(-time.Hour)})
+	fc.AddWorkflowRun(web, models.WorkflowRun{WorkflowName: "CI", HeadBranch: "dev", HeadSHA: "new",
+		Status: "in_progress", CreatedAt: now.Add(-time.Minute)})
+
+	m := fetchOpenPRs(t, newTestModel(t, fc))
+	m, _ = press(t, m, "w")
+	m, _ = press(t, m, "space")
+	m, _ = press(t, m, "enter")
+	m, cmd := press(t, m, "y")
+
+	// A green run from an earlier push doesn't count while the head commit's run is going
+	m, _ = send(t, m, runOne[mergeChecksPolledMsg](t, cmd))
+	if e := m.mergeWaitEntries[0]; e.state != mergeWaitChecksRunning || e.detail != "CI" {
+		t.Fatalf("entry = %+v, want checks running", e)
+	}
+
+	fc.AddWorkflowRun(web, models.WorkflowRun{WorkflowName: "CI", HeadBranch: "dev", HeadSHA: "new",
+		Status: "completed", Conclusion: "success", CreatedAt: now})
+	m, cmd = send(t, m, mergeWaitTickMsg{})
+	m, cmd = send(t, m, runOne[mergeChecksPolledMsg](t, cmd))
+	if e := m.mergeWaitEntries[0]; e.state != mergeWaitMerging {
+		t.Fatalf("entry = %+v, want merging", e)
+	}
+
+	m, _ = send(t, m, runOne[mergeWaitMergedMsg](t, cmd))
+	if m.screen != ScreenMergeSummary || len(m.mergeResults) != 1 || !m.mergeResults[0].Success {
+		t.Errorf("screen = %v, results = %+v", m.screen, m.mergeResults)
+	}
+}
+
+func TestPullSummaryResetKey(t *testing.T) {
+	m := newTestModel(t, github.NewFakeClient())
+	m.screen = ScreenPullSummary
+	m.pullResults = map[int]models.PullResult{0: {Status: models.PullAhead}}
+
+	m, _ = press(t, m, "r")
+	if m.screen != ScreenPullResetConfirmation || m.confirmSelection != 1 {
+		t.Errorf("screen = %v, selection = %d; want reset confirmation defaulting to No", m.screen, m.confirmSelection)
+	}
+}
+
+func TestPullBranchSelectHint(t *testing.T) {
+	m := newTestModel(t, github.NewFakeClient())
+	m.config.Flow.Stages = []string{"dev", "qa", "staging", "main"}
+	m.screen = ScreenPullBranchSelect
+
+	if view := m.View(); !strings.Contains(view, "1-4") || strings.Contains(view, "1-3") {
+		t.Errorf("hint doesn't match the 4 stage flow:\n%s", view)
+	}
+}
@@ -2,12 +2,14 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"regexp"
 	"runtime"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
@@ -17,6 +19,7 @@ import (
 	"github.com/wahlandcase/attuned.prmanager/internal/git"
 	"github.com/wahlandcase/attuned.prmanager/internal/github"
 	"github.com/wahlandcase/attuned.prmanager/internal/models"
+	"github.com/wahlandcase/attuned.prmanager/internal/release"
 	"github.com/wahlandcase/attuned.prmanager/internal/update"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -37,7 +40,8 @@ type prCreatedResult struct {
 }
 
 type batchRepoResult struct {
-	result models.BatchResult
+	repoIndex int
+	result    models.BatchResult
 }
 
 type openPRsFetchedResult struct {
@@ -46,17 +50,18 @@ type openPRsFetchedResult struct {
 }
 
 type mergeCompleteResult struct {
-	result models.MergeResult
+	prIndex int
+	result  models.MergeResult
 }
 
 type authCheckResult struct {
 	err error
 }
 
-// authCheckCmd runs gh auth check in the background
-func authCheckCmd() tea.Cmd {
+// authCheckCmd runs the GitHub auth check in the background
+func authCheckCmd(client github.Client) tea.Cmd {
 	return func() tea.Msg {
-		err := github.CheckAuth()
+		err := client.CheckAuth()
 		return authCheckResult{err: err}
 	}
 }
@@ -135,28 +140,29 @@ type batchCommitsResult struct {
 
 // batchProgressMsg is sent for real-time progress updates during batch processing
 type batchProgressMsg struct {
-	step string
+	repoIndex int
+	step      string
 }
 
 // listenForProgress creates a subscription that listens to the progress channel
-func listenForProgress(ch chan string) tea.Cmd {
+func listenForProgress(ch chan batchProgressMsg) tea.Cmd {
 	return func() tea.Msg {
 		if ch == nil {
 			return nil
 		}
-		step, ok := <-ch
+		msg, ok := <-ch
 		if !ok {
 			return nil
 		}
-		return batchProgressMsg{step: step}
+		return msg
 	}
 }
 
 // Commands
 
-func fetchCommitsCmd(repo *models.RepoInfo, prType *models.PrType, ticketRegex *regexp.Regexp, dryRun bool) tea.Cmd {
+func fetchCommitsCmd(client github.Client, repo *models.RepoInfo, prType *models.PrType, ticketRegex *regexp.Regexp, dryRun bool) tea.Cmd {
 	return func() tea.Msg {
-		// Dry run mode: return fake commits
+		// Dry run mode: return fake commits (no git fetch)
 		if dryRun {
 			time.Sleep(800 * time.Millisecond)
 			commits := []models.CommitInfo{
@@ -174,7 +180,7 @@ func fetchCommitsCmd(repo *models.RepoInfo, prType *models.PrType, ticketRegex *
 			return fetchCommitsResult{err: nil}
 		}
 
-		headBranch := prType.HeadBranch()
+		headBranch := prType.HeadBranch(repo.MainBranch)
 		baseBranch := prType.BaseBranch(repo.MainBranch)
 
 		// Fetch branches from remote
@@ -183,7 +189,7 @@ func fetchCommitsCmd(repo *models.RepoInfo, prType *models.PrType, ticketRegex *
 		}
 
 		// Get commits between branches
-		commits, err := git.GetCommitsBetween(repo.Path, baseBranch, headBranch, ticketRegex)
+		commits, err := git.GetCommitsBetween(repo.Path, baseBranch, headBranch, repo.Settings.TicketRegexOr(ticketRegex))
 		if err != nil {
 			return fetchCommitsResult{err: err}
 		}
@@ -192,26 +198,14 @@ func fetchCommitsCmd(repo *models.RepoInfo, prType *models.PrType, ticketRegex *
 		tickets := git.GetAllTickets(commits)
 
 		// Check for existing PR
-		existingPR, _ := github.GetExistingPR(repo.Path, headBranch, baseBranch)
+		existingPR, _ := client.GetExistingPR(repo.Path, headBranch, baseBranch)
 
 		return fetchCommitsResult{commits: commits, tickets: tickets, existingPR: existingPR}
 	}
 }
 
-func fetchBatchCommitsCmd(repos []models.RepoInfo, selected []bool, cachedCommits []*[]models.CommitInfo, prType *models.PrType, dryRun bool) tea.Cmd {
+func fetchBatchCommitsCmd(client github.Client, repos []models.RepoInfo, selected []bool, cachedCommits []*[]models.CommitInfo, prType *models.PrType) tea.Cmd {
 	return func() tea.Msg {
-		if dryRun {
-			time.Sleep(300 * time.Millisecond)
-			// Count selected repos for dry run
-			selectedCount := 0
-			for i := range repos {
-				if i < len(selected) && selected[i] {
-					selectedCount++
-				}
-			}
-			return batchCommitsResult{tickets: []string{"ATT-1234", "ATT-1235", "ATT-1236"}, existingPRs: 1, reposWithCommits: selectedCount}
-		}
-
 		if prType == nil {
 			return batchCommitsResult{err: nil}
 		}
@@ -248,11 +242,16 @@ func fetchBatchCommitsCmd(repos []models.RepoInfo, selected []bool, cachedCommit
 			go func(r models.RepoInfo) {
 				defer wg.Done()
 
-				headBranch := prType.HeadBranch()
-				baseBranch := prType.BaseBranch(r.MainBranch)
+				repoType, ok := r.Flow.Resolve(*prType)
+				if !ok {
+					results <- repoResult{}
+					return
+				}
+				headBranch := repoType.HeadBranch(r.MainBranch)
+				baseBranch := repoType.BaseBranch(r.MainBranch)
 
 				// Check for existing PR (no need to re-fetch commits)
-				existingPR, _ := github.GetExistingPR(r.Path, headBranch, baseBranch)
+				existingPR, _ := client.GetExistingPR(r.Path, headBranch, baseBranch)
 
 				results <- repoResult{hasExisting: existingPR != nil}
 			}(sr.repo)
@@ -293,27 +292,17 @@ func fetchBatchCommitsCmd(repos []models.RepoInfo, selected []bool, cachedCommit
 	}
 }
 
-func createPRCmd(repo *models.RepoInfo, prType *models.PrType, title string, tickets []string, linearOrg string, dryRun bool) tea.Cmd {
+func createPRCmd(client github.Client, repo *models.RepoInfo, prType *models.PrType, title, body string) tea.Cmd {
 	return func() tea.Msg {
-		// Dry run mode: return fake URL
-		if dryRun {
-			time.Sleep(1500 * time.Millisecond)
-			repoName := "example-repo"
-			if repo != nil {
-				repoName = repo.DisplayName
-			}
-			return prCreatedResult{url: "https://github.com/example/" + repoName + "/pull/123 (DRY RUN)"}
-		}
-
 		if repo == nil || prType == nil {
 			return prCreatedResult{err: nil}
 		}
 
-		headBranch := prType.HeadBranch()
+		headBranch := prType.HeadBranch(repo.MainBranch)
 		baseBranch := prType.BaseBranch(repo.MainBranch)
 
 		// Create or update PR
-		pr, _, err := github.CreateOrUpdatePR(repo.Path, headBranch, baseBranch, title, tickets, linearOrg)
+		pr, _, err := github.CreateOrUpdatePR(client, *repo, headBranch, baseBranch, title, body)
 		if err != nil {
 			return prCreatedResult{err: err}
 		}
@@ -322,103 +311,33 @@ func createPRCmd(repo *models.RepoInfo, prType *models.PrType, title string, tic
 	}
 }
 
-func fetchOpenPRsCmd(cfg *config.Config, dryRun bool) tea.Cmd {
+func fetchOpenPRsCmd(client github.Client, cfg *config.Config, dryRun bool) tea.Cmd {
 	return func() tea.Msg {
-		// Dry run mode: return fake PRs
-		if dryRun {
-			time.Sleep(1000 * time.Millisecond)
-			entries := []OpenPREntry{
-				{
-					Repo: models.RepoInfo{
-						Path:        "/home/user/repos/frontend/web",
-						DisplayName: "frontend/web",
-						MainBranch:  "main",
-					},
-					Status: models.RepoPrStatus{
-						DevToStaging: &models.GhPr{
-							Number: 123,
-							URL:    "https://github.com/example/web/pull/123",
-							Title:  "dev → staging",
-							State:  "open",
-						},
-						StagingToMain: &models.GhPr{
-							Number: 124,
-							URL:    "https://github.com/example/web/pull/124",
-							Title:  "staging → main",
-							State:  "open",
-						},
-					},
-				},
-				{
-					Repo: models.RepoInfo{
-						Path:        "/home/user/repos/backend/api",
-						DisplayName: "backend/api",
-						MainBranch:  "main",
-					},
-					Status: models.RepoPrStatus{
-						DevToStaging: &models.GhPr{
-							Number: 456,
-							URL:    "https://github.com/example/api/pull/456",
-							Title:  "dev → staging",
-							State:  "open",
-						},
-					},
-				},
+		// Find all repos (dry run uses fake repos known to the fake client)
+		repos := DryRunRepos(cfg)
+		if !dryRun {
+			var err error
+			repos, err = findRepos(cfg)
+			if err != nil {
+				return openPRsFetchedResult{err: err}
 			}
-			return openPRsFetchedResult{entries: entries}
 		}
 
-		// Find all repos
-		repos, err := git.FindAttunedRepos(cfg.AttunedPath(), cfg.Paths.FrontendGlob, cfg.Paths.BackendGlob)
+		// Fetch open PRs for all repos in batched queries
+		statuses, err := client.ListOpenReleasePRs(repos)
 		if err != nil {
 			return openPRsFetchedResult{err: err}
 		}
 
-		// Fetch open PRs in parallel
-		type result struct {
-			entry  OpenPREntry
-			hasAny bool
-			err    error
-		}
-
-		var wg sync.WaitGroup
-		results := make(chan result, len(repos))
-
-		for _, repo := range repos {
-			wg.Add(1)
-			go func(r models.RepoInfo) {
-				defer wg.Done()
-
-				status, err := github.GetOpenReleasePRs(r.Path, r.MainBranch)
-				if err != nil {
-					results <- result{err: fmt.Errorf("%s: %w", r.DisplayName, err)}
-					return
-				}
-				hasAny := status.DevToStaging != nil || status.StagingToMain != nil
-
-				results <- result{
-					entry:  OpenPREntry{Repo: r, Status: *status},
-					hasAny: hasAny,
-				}
-			}(repo)
-		}
-
-		// Close results channel when all goroutines complete
-		go func() {
-			wg.Wait()
-			close(results)
-		}()
-
-		// Collect results, filtering to only repos with open PRs
-		// Skip repos that error (e.g., no remotes) instead of failing entirely
+		// Map back in discovery order, keeping only repos with open PRs.
+		// Repos missing from the result (e.g., no remotes) are skipped.
 		var entries []OpenPREntry
-		for res := range results {
-			if res.err != nil {
-				continue // Skip problematic repos
-			}
-			if res.hasAny {
-				entries = append(entries, res.entry)
+		for _, r := range repos {
+			status, ok := statuses[r.Path]
+			if !ok || !status.HasAny() {
+				continue
 			}
+			entries = append(entries, OpenPREntry{Repo: r, Status: status})
 		}
 
 		return openPRsFetchedResult{entries: entries}
@@ -426,145 +345,69 @@ func fetchOpenPRsCmd(cfg *config.Config, dryRun bool) tea.Cmd {
 }
 
 // sendProgress safely sends a progress update to the channel
-func sendProgress(ch chan string, step string) {
+func sendProgress(ch chan batchProgressMsg, repoIndex int, step string) {
 	if ch != nil {
 		select {
-		case ch <- step:
+		case ch <- batchProgressMsg{repoIndex: repoIndex, step: step}:
 		default:
 			// Channel full or closed, skip
 		}
 	}
 }
 
+// startBatchProcessingCmd creates or updates the PR for one selected repo. It runs alongside
+// other repos' commands, so it only captures values from the model.
 func startBatchProcessingCmd(m *Model, repoIndex int) tea.Cmd {
-	return func() tea.Msg {
-		if repoIndex >= len(m.batchRepos) {
-			return nil
-		}
-
-		repo := m.batchRepos[repoIndex]
-		progressCh := m.batchProgressChan
-
-		if repoIndex >= len(m.batchSelected) || !m.batchSelected[repoIndex] {
-			// Skip unselected repos
-			return batchRepoResult{result: models.BatchResult{
-				Repo:   repo,
-				Status: models.Skipped("Not selected"),
-			}}
-		}
-
-		if m.dryRun {
-			sendProgress(progressCh, "Simulating PR creation...")
-			time.Sleep(500 * time.Millisecond)
-			url := "https://github.com/example/" + repo.DisplayName + "/pull/123 (DRY RUN)"
-			return batchRepoResult{result: models.BatchResult{
-				Repo:   repo,
-				Status: models.Created,
-				PrURL:  &url,
-			}}
+	client, repo, prType, progressCh := m.gh, m.batchRepos[repoIndex], m.prType, m.batchProgressChan
+
+	opts := release.CreateOptions{
+		Title:       m.prTitle,
+		Tracker:     m.config.Tracker(),
+		Sprint:      m.config.Sprint.Number(time.Now()),
+		Changelog:   m.prChangelog,
+		TicketRegex: m.config.TicketRegex(),
+		Progress:    func(step string) { sendProgress(progressCh, repoIndex, step) },
+	}
+	if m.dryRun {
+		// Dry run: reuse the fake commits from repo selection (no git fetch)
+		opts.Commits = &[]models.CommitInfo{}
+		if repoIndex < len(m.batchRepoCommits) && m.batchRepoCommits[repoIndex] != nil {
+			opts.Commits = m.batchRepoCommits[repoIndex]
 		}
+	}
 
+	return func() tea.Msg {
 		// Use the selected PR type
-		if m.prType == nil {
-			return batchRepoResult{result: models.BatchResult{
+		if prType == nil {
+			return batchRepoResult{repoIndex: repoIndex, result: models.BatchResult{
 				Repo:   repo,
 				Status: models.Failed("No PR type selected"),
 			}}
 		}
-		prType := *m.prType
-		headBranch := prType.HeadBranch()
-		baseBranch := prType.BaseBranch(repo.MainBranch)
-
-		// Fetch branches
-		sendProgress(progressCh, "Fetching branches...")
-		if err := git.FetchBranches(repo.Path, []string{headBranch, baseBranch}); err != nil {
-			return batchRepoResult{result: models.BatchResult{
-				Repo:   repo,
-				Status: models.Failed(err.Error()),
-			}}
-		}
-
-		// Get commits
-		sendProgress(progressCh, "Getting commits...")
-		commits, err := git.GetCommitsBetween(repo.Path, baseBranch, headBranch, m.config.TicketRegex())
-		if err != nil {
-			return batchRepoResult{result: models.BatchResult{
-				Repo:   repo,
-				Status: models.Failed(err.Error()),
-			}}
-		}
-
-		if len(commits) == 0 {
-			return batchRepoResult{result: models.BatchResult{
-				Repo:   repo,
-				Status: models.Skipped("No commits to merge"),
-			}}
-		}
-
-		tickets := git.GetAllTickets(commits)
-
-		// Create or update PR
-		sendProgress(progressCh, "Creating PR...")
-		pr, updated, err := github.CreateOrUpdatePR(repo.Path, headBranch, baseBranch, m.prTitle, tickets, m.config.Tickets.LinearOrg)
-		if err != nil {
-			return batchRepoResult{result: models.BatchResult{
-				Repo:   repo,
-				Status: models.Failed(err.Error()),
-			}}
-		}
-
-		status := models.Created
-		if updated {
-			status = models.Updated
-		}
+		return batchRepoResult{repoIndex: repoIndex, result: release.CreatePR(client, repo, *prType, opts)}
+	}
+}
 
-		return batchRepoResult{result: models.BatchResult{
-			Repo:    repo,
-			Status:  status,
-			PrURL:   &pr.URL,
-			Tickets: tickets,
-		}}
+// startNextBatchRepos starts queued repos until the worker limit is reached
+func (m *Model) startNextBatchRepos() tea.Cmd {
+	var cmds []tea.Cmd
+	for len(m.batchQueue) > 0 && len(m.batchInFlight) < m.config.Concurrency.BatchOrDefault() {
+		repoIndex := m.batchQueue[0]
+		m.batchQueue = m.batchQueue[1:]
+		m.batchInFlight[repoIndex] = ""
+		cmds = append(cmds, startBatchProcessingCmd(m, repoIndex))
 	}
+	return tea.Batch(cmds...)
 }
 
 func startMergingCmd(m *Model, prIndex int) tea.Cmd {
+	client, pr, auto := m.gh, m.mergePRs[prIndex], m.mergeAuto
 	return func() tea.Msg {
-		if prIndex >= len(m.mergePRs) {
-			return nil
-		}
-
-		pr := m.mergePRs[prIndex]
-		if prIndex >= len(m.mergeSelected) || !m.mergeSelected[prIndex] {
-			// Skip unselected PRs
-			return nil
+		// Merge the PR (or hand it to GitHub auto-merge)
+		if auto {
+			return mergeCompleteResult{prIndex: prIndex, result: release.EnableAutoMerge(client, pr)}
 		}
-
-		if m.dryRun {
-			time.Sleep(500 * time.Millisecond)
-			return mergeCompleteResult{result: models.MergeResult{
-				RepoName: pr.Repo.DisplayName,
-				PrNumber: pr.PrNumber,
-				Success:  true,
-			}}
-		}
-
-		// Merge the PR
-		err := github.MergePR(pr.Repo.Path, pr.PrNumber)
-		if err != nil {
-			errStr := err.Error()
-			return mergeCompleteResult{result: models.MergeResult{
-				RepoName: pr.Repo.DisplayName,
-				PrNumber: pr.PrNumber,
-				Success:  false,
-				Error:    &errStr,
-			}}
-		}
-
-		return mergeCompleteResult{result: models.MergeResult{
-			RepoName: pr.Repo.DisplayName,
-			PrNumber: pr.PrNumber,
-			Success:  true,
-		}}
+		return mergeCompleteResult{prIndex: prIndex, result: release.MergePR(client, pr)}
 	}
 }
 
@@ -589,7 +432,7 @@ type currentRepoLoadedResult struct {
 // loadBatchReposCmd loads repos and starts background commit fetching
 func loadBatchReposCmd(cfg *config.Config, prType *models.PrType, dryRun bool, resultsChan chan batchRepoCommitResult) tea.Cmd {
 	return func() tea.Msg {
-		repos, err := git.FindAttunedRepos(cfg.AttunedPath(), cfg.Paths.FrontendGlob, cfg.Paths.BackendGlob)
+		repos, err := findRepos(cfg)
 		if err != nil {
 			return batchReposLoadedResult{err: err}
 		}
@@ -630,12 +473,15 @@ func loadBatchReposCmd(cfg *config.Config, prType *models.PrType, dryRun bool, r
 							}
 						}
 					} else if prType != nil {
-						headBranch := prType.HeadBranch()
-						baseBranch := prType.BaseBranch(r.MainBranch)
-
-						// Fetch from remote (network call)
-						if err := git.FetchBranches(r.Path, []string{headBranch, baseBranch}); err == nil {
-							commits, _ = git.GetCommitsBetween(r.Path, baseBranch, headBranch, cfg.TicketRegex())
+						// Repos whose flow has no matching promotion have nothing to merge
+						if repoType, ok := r.Flow.Resolve(*prType); ok {
+							headBranch := repoType.HeadBranch(r.MainBranch)
+							baseBranch := repoType.BaseBranch(r.MainBranch)
+
+							// Fetch from remote (network call)
+							if err := git.FetchBranches(r.Path, []string{headBranch, baseBranch}); err == nil {
+								commits, _ = git.GetCommitsBetween(r.Path, baseBranch, headBranch, r.Settings.TicketRegexOr(cfg.TicketRegex()))
+							}
 						}
 					}
 
@@ -666,16 +512,47 @@ func listenForBatchCommits(resultsChan chan batchRepoCommitResult) tea.Cmd {
 }
 
 // loadCurrentRepoCmd loads info for the current repository
-func loadCurrentRepoCmd() tea.Cmd {
+func loadCurrentRepoCmd(cfg *config.Config) tea.Cmd {
 	return func() tea.Msg {
 		repo, err := git.GetCurrentRepoInfo()
 		if err != nil {
 			return currentRepoLoadedResult{err: err}
 		}
+		// Exclusion only applies to discovery; single mode always works on the current repo
+		if _, err := cfg.ResolveRepo(repo); err != nil {
+			return currentRepoLoadedResult{repo: repo, err: err}
+		}
 		return currentRepoLoadedResult{repo: repo}
 	}
 }
 
+// findRepos discovers all attuned repos with per-repo config applied
+func findRepos(cfg *config.Config) ([]models.RepoInfo, error) {
+	return release.FindRepos(cfg)
+}
+
+// repoGroupNames returns the distinct groups of repos in order of first appearance
+func repoGroupNames(repos []models.RepoInfo) []string {
+	var names []string
+	for _, r := range repos {
+		if !slices.Contains(names, r.Group) {
+			names = append(names, r.Group)
+		}
+	}
+	return names
+}
+
+// resolveRepos returns a copy of the dry run repos with per-repo config applied
+func resolveRepos(cfg *config.Config, repos []models.RepoInfo) []models.RepoInfo {
+	var result []models.RepoInfo
+	for _, r := range repos {
+		if keep, err := cfg.ResolveRepo(&r); err == nil && keep {
+			result = append(result, r)
+		}
+	}
+	return result
+}
+
 // Result handlers
 
 func (m Model) handleBatchReposLoaded(msg batchReposLoadedResult) (tea.Model, tea.Cmd) {
@@ -691,9 +568,9 @@ func (m Model) handleBatchReposLoaded(msg batchReposLoadedResult) (tea.Model, te
 	m.batchFetchCancel = msg.cancelFunc
 	m.batchFetchPending = len(msg.repos)
 	m.screen = ScreenBatchRepoSelect
+	m.batchGroups = repoGroupNames(msg.repos)
 	m.batchColumn = 0
-	m.batchFEIndex = 0
-	m.batchBEIndex = 0
+	m.batchColumnIndex = make([]int, len(m.batchGroups))
 
 	// Start listening for commit results
 	if len(msg.repos) > 0 && m.batchResultsChan != nil {
@@ -725,7 +602,7 @@ func (m Model) handleBatchRepoCommitResult(msg batchRepoCommitResult) (tea.Model
 			// All selected repos done - cancel remaining and proceed
 			m.cancelBatchFetch()
 			m.loadingMessage = "Checking for existing PRs..."
-			return m, fetchBatchCommitsCmd(m.batchRepos, m.batchSelected, m.batchRepoCommits, m.prType, m.dryRun)
+			return m, fetchBatchCommitsCmd(m.gh, m.batchRepos, m.batchSelected, m.batchRepoCommits, m.prType)
 		}
 		// Still waiting - keep listening
 		return m, listenForBatchCommits(m.batchResultsChan)
@@ -741,6 +618,10 @@ func (m Model) handleBatchRepoCommitResult(msg batchRepoCommitResult) (tea.Model
 func (m Model) handleCurrentRepoLoaded(msg currentRepoLoadedResult) (tea.Model, tea.Cmd) {
 	if msg.err != nil {
 		m.errorMessage = "Not in a git repository: " + msg.err.Error()
+		if msg.repo != nil {
+			// Found the repo, but its config is invalid
+			m.errorMessage = msg.err.Error()
+		}
 		m.screen = ScreenError
 		return m, nil
 	}
@@ -780,15 +661,15 @@ func (m Model) handleBatchCommitsResult(msg batchCommitsResult) (tea.Model, tea.
 	return m, nil
 }
 
-// recordSessionPR adds a PR to session history if it's a staging→main PR
+// recordSessionPR adds a PR to session history if it's a production release PR
 func (m *Model) recordSessionPR(repoName, url string) {
-	if m.prType == nil || *m.prType != models.StagingToMain {
+	if m.prType == nil || !m.prType.Final {
 		return
 	}
 	m.sessionPRs = append(m.sessionPRs, sessionPR{
 		repoName:  repoName,
 		url:       url,
-		prType:    "staging→main",
+		prType:    m.prType.Head + "→" + m.prType.Base,
 		createdAt: time.Now(),
 	})
 	saveHistory(m.sessionPRs)
@@ -812,22 +693,17 @@ func (m Model) handlePrCreatedResult(msg prCreatedResult) (tea.Model, tea.Cmd) {
 }
 
 func (m Model) handleBatchRepoResult(msg batchRepoResult) (tea.Model, tea.Cmd) {
-	// Only add non-skipped "not selected" results to keep summary clean
-	if !models.IsStatusSkipped(msg.result.Status) || models.GetStatusReason(msg.result.Status) != "Not selected" {
-		m.batchResults = append(m.batchResults, msg.result)
-	}
+	delete(m.batchInFlight, msg.repoIndex)
+	m.batchResults = append(m.batchResults, msg.result)
 
 	// Add successful PRs to session history
 	if models.IsStatusSuccess(msg.result.Status) && msg.result.PrURL != nil {
 		m.recordSessionPR(msg.result.Repo.DisplayName, *msg.result.PrURL)
 	}
-	m.batchCurrent++
 
-	// Process all repos, not just selected count
-	if m.batchCurrent >= len(m.batchRepos) {
-		m.batchCurrentRepo = ""
-		m.batchCurrentStep = ""
-		// Close progress channel
+	// Done once nothing is queued or in flight
+	if len(m.batchQueue) == 0 && len(m.batchInFlight) == 0 {
+		// Close progress channel (every worker has finished sending)
 		if m.batchProgressChan != nil {
 			close(m.batchProgressChan)
 			m.batchProgressChan = nil
@@ -844,13 +720,8 @@ func (m Model) handleBatchRepoResult(msg batchRepoResult) (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
-	// Set next repo name, clear step, and start processing
-	m.batchCurrentRepo = m.batchRepos[m.batchCurrent].DisplayName
-	m.batchCurrentStep = ""
-	return m, tea.Batch(
-		startBatchProcessingCmd(&m, m.batchCurrent),
-		listenForProgress(m.batchProgressChan),
-	)
+	// Hand the free worker slot to the next queued repo
+	return m, m.startNextBatchRepos()
 }
 
 func (m Model) handleOpenPRsFetchedResult(msg openPRsFetchedResult) (tea.Model, tea.Cmd) {
@@ -866,56 +737,32 @@ func (m Model) handleOpenPRsFetchedResult(msg openPRsFetchedResult) (tea.Model,
 	// Build merge PR list
 	m.mergePRs = nil
 	for _, entry := range m.openPRs {
-		if entry.Status.DevToStaging != nil {
-			m.mergePRs = append(m.mergePRs, models.MergePrEntry{
-				Repo:     entry.Repo,
-				PrNumber: entry.Status.DevToStaging.Number,
-				PrTitle:  entry.Status.DevToStaging.Title,
-				URL:      entry.Status.DevToStaging.URL,
-				PrType:   models.DevToStaging,
-			})
-		}
-		if entry.Status.StagingToMain != nil {
-			m.mergePRs = append(m.mergePRs, models.MergePrEntry{
-				Repo:     entry.Repo,
-				PrNumber: entry.Status.StagingToMain.Number,
-				PrTitle:  entry.Status.StagingToMain.Title,
-				URL:      entry.Status.StagingToMain.URL,
-				PrType:   models.StagingToMain,
-			})
-		}
+		m.mergePRs = append(m.mergePRs, release.MergeEntries(entry.Repo, entry.Status)...)
 	}
 
 	m.mergeSelected = make([]bool, len(m.mergePRs))
+	m.mergeOverride = false
+	m.mergeAuto = false
+	m.mergeWait = false
 	m.mergeColumn = 0
-	m.mergeDevIndex = 0
-	m.mergeMainIndex = 0
+	m.mergeColumnIndex = make([]int, len(m.config.ReleaseFlow().PrTypes()))
 
 	return m, nil
 }
 
 func (m Model) handleMergeCompleteResult(msg mergeCompleteResult) (tea.Model, tea.Cmd) {
 	m.mergeResults = append(m.mergeResults, msg.result)
-	m.mergeCurrent++
-
-	if m.mergeCurrent >= m.mergeTotal {
-		m.screen = ScreenMergeSummary
-		m.menuIndex = 0
-		return m, nil
-	}
-
-	// Find next selected PR to merge
-	for i := m.mergeCurrent; i < len(m.mergePRs); i++ {
-		if i < len(m.mergeSelected) && m.mergeSelected[i] {
-			return m, startMergingCmd(&m, i)
+	if msg.result.AutoMerge {
+		for i, pr := range m.mergePRs {
+			if pr.Repo.DisplayName == msg.result.RepoName && pr.PrNumber == msg.result.PrNumber {
+				m.mergePRs[i].AutoMerge = true
+			}
 		}
-		m.mergeCurrent++
 	}
+	m.recordMergeOutcome(msg.prIndex, msg.result.Success)
+	m.mergeCurrent++
 
-	// No more PRs to merge
-	m.screen = ScreenMergeSummary
-	m.menuIndex = 0
-	return m, nil
+	return m.mergeNext()
 }
 
 // openURL opens a URL in the default browser
@@ -978,7 +825,8 @@ func openURLs(urls []string) {
 // Pull all repos messages and commands
 
 type pullRepoResult struct {
-	result models.PullResult
+	repoIndex int
+	result    models.PullResult
 }
 
 type pullReposLoadedResult struct {
@@ -986,20 +834,20 @@ type pullReposLoadedResult struct {
 	err   error
 }
 
-// makePullResult creates a pullRepoResult with the given status
-func makePullResult(repo models.RepoInfo, status models.PullStatus, commits int, errMsg string) pullRepoResult {
-	return pullRepoResult{result: models.PullResult{
+// makePullResult creates a PullResult with the given status
+func makePullResult(repo models.RepoInfo, status models.PullStatus, commits int, errMsg string) models.PullResult {
+	return models.PullResult{
 		Repo:        repo,
 		Status:      status,
 		CommitCount: commits,
 		Error:       errMsg,
-	}}
+	}
 }
 
 // loadPullReposCmd loads all repos for pull operation
 func loadPullReposCmd(cfg *config.Config) tea.Cmd {
 	return func() tea.Msg {
-		repos, err := git.FindAttunedRepos(cfg.AttunedPath(), cfg.Paths.FrontendGlob, cfg.Paths.BackendGlob)
+		repos, err := findRepos(cfg)
 		if err != nil {
 			return pullReposLoadedResult{err: err}
 		}
@@ -1007,68 +855,232 @@ func loadPullReposCmd(cfg *config.Config) tea.Cmd {
 	}
 }
 
-// pullNextRepoCmd pulls the next repo in the list
-func pullNextRepoCmd(repo models.RepoInfo, branch string, dryRun bool) tea.Cmd {
+// pullOptions controls how pull all treats each repo
+type pullOptions struct {
+	autoStash bool   // Stash local changes around the pull instead of skipping dirty repos
+	mode      string // config.PullMode*
+	dryRun    bool
+}
+
+// pullRepoCmd pulls one repo; several run at once. stageIdx is a stage of the global
+// flow, mapped onto the repo's own flow (and "main" onto its actual main branch).
+func pullRepoCmd(repoIndex int, repo models.RepoInfo, globalFlow models.Flow, stageIdx int, opts pullOptions) tea.Cmd {
 	return func() tea.Msg {
-		// For "main", use the repo's actual main branch (could be "master")
-		targetBranch := branch
-		if branch == "main" {
-			targetBranch = repo.MainBranch
-		}
+		return pullRepoResult{repoIndex: repoIndex, result: pullRepo(repo, globalFlow, stageIdx, opts)}
+	}
+}
 
-		if dryRun {
-			time.Sleep(200 * time.Millisecond)
-			// Simulate various results for dry run
-			hash := 0
-			for _, c := range repo.DisplayName {
-				hash += int(c)
-			}
-			statuses := []models.PullStatus{
-				models.PullUpdated,
-				models.PullUpToDate,
-				models.PullSkippedNoBranch,
+// pullRepo fetches, checks out and fast-forwards the stage's branch in one repo
+func pullRepo(repo models.RepoInfo, globalFlow models.Flow, stageIdx int, opts pullOptions) models.PullResult {
+	stage, ok := repo.Flow.ResolveStage(globalFlow, stageIdx)
+	if !ok {
+		return makePullResult(repo, models.PullSkippedNoBranch, 0, "")
+	}
+	targetBranch := models.StageBranch(stage, repo.MainBranch)
+
+	if opts.dryRun {
+		// Simulate various results (and durations) for dry run
+		hash := 0
+		for _, c := range repo.DisplayName {
+			hash += int(c)
+		}
+		time.Sleep(time.Duration(200+(hash%4)*150) * time.Millisecond)
+		statuses := []models.PullStatus{
+			models.PullUpdated,
+			models.PullUpToDate,
+			models.PullSkippedNoBranch,
+			models.PullDiverged,
+		}
+		status := statuses[hash%len(statuses)]
+		commits := 0
+		if status == models.PullUpdated {
+			commits = (hash % 5) + 1
+			if opts.autoStash && hash%2 == 0 {
+				status = models.PullStashRestored
 			}
-			status := statuses[hash%len(statuses)]
-			commits := 0
-			if status == models.PullUpdated {
-				commits = (hash % 5) + 1
+		}
+		r := makePullResult(repo, status, commits, "")
+		r.Branch = targetBranch
+		r.OriginalBranch = targetBranch
+		if status == models.PullDiverged {
+			r.Ahead, r.Behind = 2, 3
+			r.LocalCommits = []models.CommitInfo{
+				{Hash: "a1b2c3d", Message: "wip: local experiment"},
+				{Hash: "e4f5a6b", Message: "fix: hotfix applied locally"},
 			}
-			return makePullResult(repo, status, commits, "")
 		}
+		if hash%2 == 1 {
+			r.OriginalBranch = "feature/demo"
+		}
+		return r
+	}
+
+	// Check if branch exists
+	if !git.HasBranch(repo.Path, targetBranch) {
+		return makePullResult(repo, models.PullSkippedNoBranch, 0, "")
+	}
 
-		// Check if branch exists
-		if !git.HasBranch(repo.Path, targetBranch) {
-			return makePullResult(repo, models.PullSkippedNoBranch, 0, "")
+	originalBranch, err := git.CurrentBranch(repo.Path)
+	if err != nil {
+		return makePullResult(repo, models.PullFailed, 0, err.Error())
+	}
+	result := func(status models.PullStatus, commits int, errMsg string) models.PullResult {
+		r := makePullResult(repo, status, commits, errMsg)
+		r.Branch = targetBranch
+		r.OriginalBranch = originalBranch
+		return r
+	}
+	// diverged explains a branch that couldn't be fast-forwarded (or has nothing to pull) by
+	// comparing it with origin; ok is false if it's simply in sync
+	diverged := func(errMsg string) (models.PullResult, bool) {
+		d, err := git.BranchDivergence(repo.Path, targetBranch)
+		if err != nil {
+			return models.PullResult{}, false
+		}
+		var r models.PullResult
+		switch {
+		case d.Ahead > 0 && d.Behind > 0:
+			r = result(models.PullDiverged, 0, errMsg)
+		case d.Ahead > 0:
+			r = result(models.PullAhead, 0, errMsg)
+		case d.Behind > 0 && errMsg != "":
+			r = result(models.PullBehind, 0, errMsg)
+		default:
+			return models.PullResult{}, false
 		}
+		r.Ahead, r.Behind, r.LocalCommits = d.Ahead, d.Behind, d.LocalCommits
+		return r, true
+	}
+	// failed reports a pull error, explained by how the branch differs from origin if possible
+	failed := func(err error, commits int) models.PullResult {
+		if r, ok := diverged(err.Error()); ok {
+			return r
+		}
+		return result(models.PullFailed, commits, err.Error())
+	}
 
-		// Check for dirty working tree
-		dirty, err := git.IsDirty(repo.Path)
+	// Updating the branch in place never touches the working tree, so local changes don't matter
+	inPlace := opts.mode == config.PullModeUpdate && originalBranch != targetBranch
+
+	// Check for dirty working tree
+	dirty := false
+	if !inPlace {
+		dirty, err = git.IsDirty(repo.Path)
 		if err != nil {
-			return makePullResult(repo, models.PullFailed, 0, err.Error())
+			return result(models.PullFailed, 0, err.Error())
 		}
-		if dirty {
-			return makePullResult(repo, models.PullSkippedDirty, 0, "")
+		if dirty && !opts.autoStash {
+			return result(models.PullSkippedDirty, 0, "")
 		}
+	}
 
-		// Fetch first to get remote changes
-		if err := git.FetchBranches(repo.Path, []string{targetBranch}); err != nil {
-			// If fetch fails due to branch not found, skip
-			if _, ok := err.(*git.BranchNotFoundError); ok {
-				return makePullResult(repo, models.PullSkippedNoBranch, 0, "")
-			}
-			return makePullResult(repo, models.PullFailed, 0, err.Error())
+	// Fetch first to get remote changes
+	if err := git.FetchBranches(repo.Path, []string{targetBranch}); err != nil {
+		// If fetch fails due to branch not found, skip
+		if _, ok := err.(*git.BranchNotFoundError); ok {
+			return result(models.PullSkippedNoBranch, 0, "")
 		}
+		return result(models.PullFailed, 0, err.Error())
+	}
 
-		// Checkout and pull
-		commits, err := git.CheckoutAndPull(repo.Path, targetBranch)
+	if inPlace {
+		commits, created, err := git.FastForwardBranch(repo.Path, targetBranch)
 		if err != nil {
-			return makePullResult(repo, models.PullFailed, 0, err.Error())
+			return failed(err, 0)
+		}
+		if created {
+			return result(models.PullCreated, 0, "")
 		}
-
 		if commits == 0 {
-			return makePullResult(repo, models.PullUpToDate, 0, "")
+			return result(models.PullUpToDate, 0, "")
+		}
+		return result(models.PullUpdated, commits, "")
+	}
+
+	// Stash local changes (including untracked files) so the checkout can't trip over them
+	var stash string
+	if dirty {
+		if stash, err = git.Stash(repo.Path, "attpr pull all: "+originalBranch); err != nil {
+			return result(models.PullFailed, 0, err.Error())
+		}
+	}
+
+	// Checkout and pull
+	commits, created, pullErr := git.CheckoutAndPull(repo.Path, targetBranch)
+
+	// Switch back when asked to, or before re-applying stashed changes after a failed pull
+	if originalBranch != targetBranch && (opts.mode == config.PullModeRestore || (dirty && pullErr != nil)) {
+		if err := git.Checkout(repo.Path, originalBranch); err != nil && pullErr == nil {
+			pullErr = fmt.Errorf("pulled, but couldn't switch back to %s: %w", originalBranch, err)
+		}
+	}
+
+	if dirty {
+		popErr := git.StashPop(repo.Path, stash)
+		var conflict *git.StashConflictError
+		switch {
+		case pullErr != nil && popErr != nil:
+			return result(models.PullFailed, commits, fmt.Sprintf("%s (local changes left stashed: %s)", pullErr, popErr))
+		case pullErr != nil:
+			return failed(pullErr, commits)
+		case errors.As(popErr, &conflict):
+			r := result(models.PullStashConflict, commits, conflict.Output)
+			r.StashRef = conflict.Ref
+			return r
+		case popErr != nil:
+			return result(models.PullFailed, commits, popErr.Error())
+		}
+		return result(models.PullStashRestored, commits, "")
+	}
+
+	if pullErr != nil {
+		return failed(pullErr, commits)
+	}
+	if created {
+		return result(models.PullCreated, 0, "")
+	}
+	if commits == 0 {
+		// Nothing to pull, but there may be local commits that were never pushed
+		if r, ok := diverged(""); ok {
+			return r
 		}
-		return makePullResult(repo, models.PullUpdated, commits, "")
+		return result(models.PullUpToDate, 0, "")
+	}
+	return result(models.PullUpdated, commits, "")
+}
+
+type pullResetDoneMsg struct {
+	results map[int]models.PullResult // Repo index -> updated result
+}
+
+// resetToOriginCmd resets every ahead or diverged branch to origin's copy
+func resetToOriginCmd(results map[int]models.PullResult, dryRun bool) tea.Cmd {
+	// Copy what's needed; results is the model's live map
+	toReset := make(map[int]models.PullResult)
+	for i, r := range results {
+		if r.CanResetToOrigin() {
+			toReset[i] = r
+		}
+	}
+	return func() tea.Msg {
+		for i, r := range toReset {
+			var previous string
+			var err error
+			if dryRun {
+				previous = r.LocalCommits[0].Hash
+			} else {
+				previous, err = git.ResetToOrigin(r.Repo.Path, r.Branch)
+			}
+			if err != nil {
+				r.Error = err.Error()
+			} else {
+				r.Status = models.PullReset
+				r.ResetFrom = previous
+				r.Error = ""
+			}
+			toReset[i] = r
+		}
+		return pullResetDoneMsg{results: toReset}
 	}
 }
 
@@ -1081,8 +1093,12 @@ func (m Model) handlePullReposLoaded(msg pullReposLoadedResult) (tea.Model, tea.
 	}
 
 	m.pullRepos = msg.repos
-	m.pullResults = nil
-	m.pullCurrentIdx = 0
+	m.pullResults = make(map[int]models.PullResult)
+	m.pullInFlight = make(map[int]bool)
+	m.pullQueue = make([]int, len(m.pullRepos))
+	for i := range m.pullQueue {
+		m.pullQueue[i] = i
+	}
 	m.screen = ScreenPullProgress
 
 	if len(m.pullRepos) == 0 {
@@ -1090,22 +1106,38 @@ func (m Model) handlePullReposLoaded(msg pullReposLoadedResult) (tea.Model, tea.
 		return m, nil
 	}
 
-	// Start pulling first repo
-	return m, pullNextRepoCmd(m.pullRepos[0], m.pullBranch, m.dryRun)
+	// Start the first workers
+	return m, m.startNextPulls()
+}
+
+// startNextPulls starts queued repos until the worker limit is reached
+func (m *Model) startNextPulls() tea.Cmd {
+	var cmds []tea.Cmd
+	for len(m.pullQueue) > 0 && len(m.pullInFlight) < m.config.Concurrency.PullOrDefault() {
+		i := m.pullQueue[0]
+		m.pullQueue = m.pullQueue[1:]
+		m.pullInFlight[i] = true
+		cmds = append(cmds, pullRepoCmd(i, m.pullRepos[i], m.config.ReleaseFlow(), m.pullStage,
+			pullOptions{autoStash: m.pullAutoStash, mode: m.pullMode, dryRun: m.dryRun}))
+	}
+	return tea.Batch(cmds...)
 }
 
 // handlePullRepoResult handles the result of pulling a single repo
 func (m Model) handlePullRepoResult(msg pullRepoResult) (tea.Model, tea.Cmd) {
-	m.pullResults = append(m.pullResults, msg.result)
-	m.pullCurrentIdx++
+	if m.screen != ScreenPullProgress {
+		return m, nil
+	}
+	delete(m.pullInFlight, msg.repoIndex)
+	m.pullResults[msg.repoIndex] = msg.result
 
-	if m.pullCurrentIdx >= len(m.pullRepos) {
+	if len(m.pullQueue) == 0 && len(m.pullInFlight) == 0 {
 		m.screen = ScreenPullSummary
 		return m, nil
 	}
 
-	// Pull next repo
-	return m, pullNextRepoCmd(m.pullRepos[m.pullCurrentIdx], m.pullBranch, m.dryRun)
+	// Hand the free worker slot to the next queued repo
+	return m, m.startNextPulls()
 }
 
 // GitHub Actions messages and commands
@@ -1123,32 +1155,16 @@ type actionsJobsFetchedResult struct {
 	err   error
 }
 
-func fetchActionsRunsCmd(cfg *config.Config, dryRun bool) tea.Cmd {
+func fetchActionsRunsCmd(client github.Client, cfg *config.Config, dryRun bool) tea.Cmd {
 	return func() tea.Msg {
-		if dryRun {
-			time.Sleep(800 * time.Millisecond)
-			now := time.Now()
-			fakeRepos := []models.RepoInfo{
-				{Path: "/home/user/repos/frontend/web", DisplayName: "frontend/web", MainBranch: "main"},
-				{Path: "/home/user/repos/frontend/mobile", DisplayName: "frontend/mobile", MainBranch: "main"},
-				{Path: "/home/user/repos/backend/api", DisplayName: "backend/api", MainBranch: "main"},
-				{Path: "/home/user/repos/backend/workers", DisplayName: "backend/workers", MainBranch: "main"},
+		// Dry run uses fake repos known to the fake client
+		repos := dryRunRepos
+		if !dryRun {
+			var err error
+			repos, err = findRepos(cfg)
+			if err != nil {
+				return actionsRunsFetchedResult{err: err}
 			}
-			fakeEntries := []actionsEntry{
-				{Repo: fakeRepos[0], Run: models.WorkflowRun{DatabaseID: 1001, DisplayTitle: "feat: Add dashboard", WorkflowName: "CI", Status: "in_progress", HeadBranch: "dev", Event: "push", URL: "https://github.com/example/web/actions/runs/1001", CreatedAt: now.Add(-3 * time.Minute), UpdatedAt: now.Add(-1 * time.Minute)}},
-				{Repo: fakeRepos[0], Run: models.WorkflowRun{DatabaseID: 1000, DisplayTitle: "fix: Auth bug", WorkflowName: "CI", Status: "completed", Conclusion: "success", HeadBranch: "staging", Event: "push", URL: "https://github.com/example/web/actions/runs/1000", CreatedAt: now.Add(-30 * time.Minute), UpdatedAt: now.Add(-25 * time.Minute)}},
-				{Repo: fakeRepos[1], Run: models.WorkflowRun{DatabaseID: 2001, DisplayTitle: "chore: Update deps", WorkflowName: "CI", Status: "completed", Conclusion: "failure", HeadBranch: "dev", Event: "push", URL: "https://github.com/example/mobile/actions/runs/2001", CreatedAt: now.Add(-10 * time.Minute), UpdatedAt: now.Add(-8 * time.Minute)}},
-				{Repo: fakeRepos[2], Run: models.WorkflowRun{DatabaseID: 3001, DisplayTitle: "feat: Add endpoints", WorkflowName: "CI", Status: "in_progress", HeadBranch: "dev", Event: "push", URL: "https://github.com/example/api/actions/runs/3001", CreatedAt: now.Add(-2 * time.Minute), UpdatedAt: now.Add(-30 * time.Second)}},
-				{Repo: fakeRepos[2], Run: models.WorkflowRun{DatabaseID: 3002, DisplayTitle: "Deploy staging", WorkflowName: "Deploy", Status: "queued", HeadBranch: "staging", Event: "push", URL: "https://github.com/example/api/actions/runs/3002", CreatedAt: now.Add(-1 * time.Minute), UpdatedAt: now.Add(-1 * time.Minute)}},
-				{Repo: fakeRepos[2], Run: models.WorkflowRun{DatabaseID: 3000, DisplayTitle: "fix: DB migration", WorkflowName: "CI", Status: "completed", Conclusion: "success", HeadBranch: "main", Event: "push", URL: "https://github.com/example/api/actions/runs/3000", CreatedAt: now.Add(-1 * time.Hour), UpdatedAt: now.Add(-55 * time.Minute)}},
-				{Repo: fakeRepos[3], Run: models.WorkflowRun{DatabaseID: 4001, DisplayTitle: "refactor: Queue handler", WorkflowName: "CI", Status: "completed", Conclusion: "cancelled", HeadBranch: "dev", Event: "push", URL: "https://github.com/example/workers/actions/runs/4001", CreatedAt: now.Add(-15 * time.Minute), UpdatedAt: now.Add(-12 * time.Minute)}},
-			}
-			return actionsRunsFetchedResult{entries: fakeEntries}
-		}
-
-		repos, err := git.FindAttunedRepos(cfg.AttunedPath(), cfg.Paths.FrontendGlob, cfg.Paths.BackendGlob)
-		if err != nil {
-			return actionsRunsFetchedResult{err: err}
 		}
 
 		type repoResult struct {
@@ -1163,7 +1179,7 @@ func fetchActionsRunsCmd(cfg *config.Config, dryRun bool) tea.Cmd {
 			wg.Add(1)
 			go func(r models.RepoInfo) {
 				defer wg.Done()
-				runs, err := github.ListWorkflowRuns(r.Path, 10)
+				runs, err := client.ListWorkflowRuns(r.Path, 10)
 				if err != nil {
 					results <- repoResult{repo: r}
 					return
@@ -1210,46 +1226,9 @@ func actionsRefreshTickCmd() tea.Cmd {
 	})
 }
 
-func fetchActionsJobsCmd(repoPath string, runID uint64, dryRun bool) tea.Cmd {
+func fetchActionsJobsCmd(client github.Client, repoPath string, runID uint64) tea.Cmd {
 	return func() tea.Msg {
-		if dryRun {
-			time.Sleep(500 * time.Millisecond)
-			now := time.Now()
-			fakeJobs := []models.WorkflowJob{
-				{
-					Name: "build", Status: "completed", Conclusion: "success",
-					StartedAt: now.Add(-5 * time.Minute), CompletedAt: now.Add(-3 * time.Minute),
-					URL: "https://github.com/example/repo/actions/runs/1001/job/1",
-					Steps: []models.WorkflowStep{
-						{Name: "Checkout", Number: 1, Status: "completed", Conclusion: "success"},
-						{Name: "Setup Node", Number: 2, Status: "completed", Conclusion: "success"},
-						{Name: "Install deps", Number: 3, Status: "completed", Conclusion: "success"},
-						{Name: "Build", Number: 4, Status: "completed", Conclusion: "success"},
-					},
-				},
-				{
-					Name: "test", Status: "in_progress", Conclusion: "",
-					StartedAt: now.Add(-2 * time.Minute),
-					URL:        "https://github.com/example/repo/actions/runs/1001/job/2",
-					Steps: []models.WorkflowStep{
-						{Name: "Checkout", Number: 1, Status: "completed", Conclusion: "success"},
-						{Name: "Setup Node", Number: 2, Status: "completed", Conclusion: "success"},
-						{Name: "Run tests", Number: 3, Status: "in_progress", Conclusion: ""},
-						{Name: "Upload coverage", Number: 4, Status: "queued", Conclusion: ""},
-					},
-				},
-				{
-					Name: "deploy", Status: "queued", Conclusion: "",
-					URL: "https://github.com/example/repo/actions/runs/1001/job/3",
-					Steps: []models.WorkflowStep{
-						{Name: "Deploy to staging", Number: 1, Status: "queued", Conclusion: ""},
-					},
-				},
-			}
-			return actionsJobsFetchedResult{runID: runID, jobs: fakeJobs}
-		}
-
-		jobs, err := github.GetWorkflowRunJobs(repoPath, runID)
+		jobs, err := client.GetWorkflowRunJobs(repoPath, runID)
 		if err != nil {
 			return actionsJobsFetchedResult{runID: runID, err: err}
 		}
@@ -1285,7 +1264,7 @@ func (m Model) handleActionsRunsFetched(msg actionsRunsFetchedResult) (tea.Model
 		for _, entry := range msg.entries {
 			if entry.Run.DatabaseID == panel.Run.DatabaseID {
 				if entry.Run.Status != panel.Run.Status || entry.Run.Status == "in_progress" || entry.Run.Status == "queued" {
-					refreshCmds = append(refreshCmds, fetchActionsJobsCmd(entry.Repo.Path, entry.Run.DatabaseID, m.dryRun))
+					refreshCmds = append(refreshCmds, fetchActionsJobsCmd(m.gh, entry.Repo.Path, entry.Run.DatabaseID))
 				}
 				m.actionsPinned[i].Run = entry.Run
 				break
@@ -1306,7 +1285,7 @@ func (m Model) handleActionsRefreshTick() (tea.Model, tea.Cmd) {
 		return m, nil // Stop tick chain
 	}
 	m.actionsLoading = true
-	return m, fetchActionsRunsCmd(m.config, m.dryRun)
+	return m, fetchActionsRunsCmd(m.gh, m.config, m.dryRun)
 }
 
 func (m Model) handleActionsJobsFetched(msg actionsJobsFetchedResult) (tea.Model, tea.Cmd) {
@@ -0,0 +1,93 @@
+package app
+
+import (
+	"time"
+
+	"github.com/wahlandcase/attuned.prmanager/internal/config"
+	"github.com/wahlandcase/attuned.prmanager/internal/github"
+	"github.com/wahlandcase/attuned.prmanager/internal/models"
+)
+
+// dryRunRepos are the fake repos used in place of repo discovery for GitHub-only views in dry run mode
+var dryRunRepos = []models.RepoInfo{
+	{Path: "/home/user/repos/frontend/web", DisplayName: "frontend/web", MainBranch: "main", Group: "frontend"},
+	{Path: "/home/user/repos/frontend/mobile", DisplayName: "frontend/mobile", MainBranch: "main", Group: "frontend"},
+	{Path: "/home/user/repos/backend/api", DisplayName: "backend/api", MainBranch: "main", Group: "backend"},
+	{Path: "/home/user/repos/backend/workers", DisplayName: "backend/workers", MainBranch: "main", Group: "backend"},
+}
+
+// NewDryRunClient creates a fake GitHub client seeded with demo PRs and workflow runs
+func NewDryRunClient() *github.FakeClient {
+	fc := github.NewFakeClient()
+	fc.Latency = 300 * time.Millisecond
+
+	web, mobile, api, workers := dryRunRepos[0].Path, dryRunRepos[1].Path, dryRunRepos[2].Path, dryRunRepos[3].Path
+
+	// Open release PRs
+	fc.AddPR(web, "dev", "staging", models.GhPr{Number: 123, URL: "https://github.com/example/web/pull/123", Title: "dev → staging",
+		Mergeable: "MERGEABLE", ReviewDecision: "APPROVED", ChecksState: "SUCCESS", HeadSHA: "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b"})
+	fc.AddPR(web, "staging", "main", models.GhPr{Number: 124, URL: "https://github.com/example/web/pull/124", Title: "staging → main",
+		Mergeable: "MERGEABLE", ReviewDecision: "REVIEW_REQUIRED", ChecksState: "PENDING", HeadSHA: "2c26b46b68ffc68ff99b453c1d30413413422d70"})
+	fc.AddPR(api, "dev", "staging", models.GhPr{Number: 456, URL: "https://github.com/example/api/pull/456", Title: "dev → staging",
+		Mergeable: "CONFLICTING", ChecksState: "FAILURE", HeadSHA: "fcde2b2edba56bf408601fb721fe9b5c338d10ee"})
+
+	// Workflow runs
+	now := time.Now()
+	runs := []struct {
+		repo string
+		run  models.WorkflowRun
+	}{
+		{web, models.WorkflowRun{DatabaseID: 1001, DisplayTitle: "feat: Add dashboard", WorkflowName: "CI", Status: "in_progress", HeadBranch: "dev", HeadSHA: "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b", Event: "push", URL: "https://github.com/example/web/actions/runs/1001", CreatedAt: now.Add(-3 * time.Minute), UpdatedAt: now.Add(-1 * time.Minute)}},
+		{web, models.WorkflowRun{DatabaseID: 1000, DisplayTitle: "fix: Auth bug", WorkflowName: "CI", Status: "completed", Conclusion: "success", HeadBranch: "staging", HeadSHA: "2c26b46b68ffc68ff99b453c1d30413413422d70", Event: "push", URL: "https://github.com/example/web/actions/runs/1000", CreatedAt: now.Add(-30 * time.Minute), UpdatedAt: now.Add(-25 * time.Minute)}},
+		{mobile, models.WorkflowRun{DatabaseID: 2001, DisplayTitle: "chore: Update deps", WorkflowName: "CI", Status: "completed", Conclusion: "failure", HeadBranch: "dev", Event: "push", URL: "https://github.com/example/mobile/actions/runs/2001", CreatedAt: now.Add(-10 * time.Minute), UpdatedAt: now.Add(-8 * time.Minute)}},
+		{api, models.WorkflowRun{DatabaseID: 3001, DisplayTitle: "feat: Add endpoints", WorkflowName: "CI", Status: "in_progress", HeadBranch: "dev", HeadSHA: "fcde2b2edba56bf408601fb721fe9b5c338d10ee", Event: "push", URL: "https://github.com/example/api/actions/runs/3001", CreatedAt: now.Add(-2 * time.Minute), UpdatedAt: now.Add(-30 * time.Second)}},
+		{api, models.WorkflowRun{DatabaseID: 3002, DisplayTitle: "Deploy staging", WorkflowName: "Deploy", Status: "queued", HeadBranch: "staging", Event: "push", URL: "https://github.com/example/api/actions/runs/3002", CreatedAt: now.Add(-1 * time.Minute), UpdatedAt: now.Add(-1 * time.Minute)}},
+		{api, models.WorkflowRun{DatabaseID: 3000, DisplayTitle: "fix: DB migration", WorkflowName: "CI", Status: "completed", Conclusion: "success", HeadBranch: "main", Event: "push", URL: "https://github.com/example/api/actions/runs/3000", CreatedAt: now.Add(-1 * time.Hour), UpdatedAt: now.Add(-55 * time.Minute)}},
+		{workers, models.WorkflowRun{DatabaseID: 4001, DisplayTitle: "refactor: Queue handler", WorkflowName: "CI", Status: "completed", Conclusion: "cancelled", HeadBranch: "dev", Event: "push", URL: "https://github.com/example/workers/actions/runs/4001", CreatedAt: now.Add(-15 * time.Minute), UpdatedAt: now.Add(-12 * time.Minute)}},
+	}
+
+	// Every run shows the same set of jobs
+	jobs := []models.WorkflowJob{
+		{
+			Name: "build", Status: "completed", Conclusion: "success",
+			StartedAt: now.Add(-5 * time.Minute), CompletedAt: now.Add(-3 * time.Minute),
+			URL: "https://github.com/example/repo/actions/runs/1001/job/1",
+			Steps: []models.WorkflowStep{
+				{Name: "Checkout", Number: 1, Status: "completed", Conclusion: "success"},
+				{Name: "Setup Node", Number: 2, Status: "completed", Conclusion: "success"},
+				{Name: "Install deps", Number: 3, Status: "completed", Conclusion: "success"},
+				{Name: "Build", Number: 4, Status: "completed", Conclusion: "success"},
+			},
+		},
+		{
+			Name: "test", Status: "in_progress", Conclusion: "",
+			StartedAt: now.Add(-2 * time.Minute),
+			URL:       "https://github.com/example/repo/actions/runs/1001/job/2",
+			Steps: []models.WorkflowStep{
+				{Name: "Checkout", Number: 1, Status: "completed", Conclusion: "success"},
+				{Name: "Setup Node", Number: 2, Status: "completed", Conclusion: "success"},
+				{Name: "Run tests", Number: 3, Status: "in_progress", Conclusion: ""},
+				{Name: "Upload coverage", Number: 4, Status: "queued", Conclusion: ""},
+			},
+		},
+		{
+			Name: "deploy", Status: "queued", Conclusion: "",
+			URL: "https://github.com/example/repo/actions/runs/1001/job/3",
+			Steps: []models.WorkflowStep{
+				{Name: "Deploy to staging", Number: 1, Status: "queued", Conclusion: ""},
+			},
+		},
+	}
+
+	for _, r := range runs {
+		fc.AddWorkflowRun(r.repo, r.run)
+		fc.SetWorkflowJobs(r.run.DatabaseID, jobs)
+	}
+
+	return fc
+}
+
+// DryRunRepos returns the fake repos known to the dry run client, with per-repo config applied
+func DryRunRepos(cfg *config.Config) []models.RepoInfo {
+	return resolveRepos(cfg, dryRunRepos)
+}
@@ -0,0 +1,250 @@
+package app
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/wahlandcase/attuned.prmanager/internal/github"
+	"github.com/wahlandcase/attuned.prmanager/internal/models"
+	"github.com/wahlandcase/attuned.prmanager/internal/release"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// mergeDeploy watches an upstream repo's deploy workflow after its PR merged
+type mergeDeploy struct {
+	mergedAt time.Time
+	state    release.CheckState
+	detail   string
+}
+
+// done returns true once the deploy has passed or failed
+func (d mergeDeploy) done() bool {
+	return d.state == release.ChecksPassed || d.state == release.ChecksFailed
+}
+
+type mergeDeploysPolledMsg struct {
+	deploys map[int]mergeCheck // PR index -> deploy state
+}
+
+// mergeDeployTarget is a deploy to poll for
+type mergeDeployTarget struct {
+	pr       models.MergePrEntry
+	mergedAt time.Time
+}
+
+// pollMergeDeploysCmd checks each upstream repo's deploy workflow on the branch its PR merged into
+func pollMergeDeploysCmd(client github.Client, targets map[int]mergeDeployTarget) tea.Cmd {
+	return func() tea.Msg {
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+		deploys := make(map[int]mergeCheck, len(targets))
+		for i, t := range targets {
+			wg.Add(1)
+			go func(i int, t mergeDeployTarget) {
+				defer wg.Done()
+				var check mergeCheck
+				runs, err := client.ListWorkflowRuns(t.pr.Repo.Path, 20)
+				if err != nil {
+					check.err = err
+				} else {
+					check.state, check.detail = release.DeployStatus(runs, t.pr.Repo.Settings.DeployWorkflow,
+						t.pr.PrType.BaseBranch(t.pr.Repo.MainBranch), t.mergedAt)
+				}
+				mu.Lock()
+				deploys[i] = check
+				mu.Unlock()
+			}(i, t)
+		}
+		wg.Wait()
+		return mergeDeploysPolledMsg{deploys: deploys}
+	}
+}
+
+// selectedMergeOrder returns the selected PR indices in merge_after order, and each
+// selected PR's dependencies among them
+func (m Model) selectedMergeOrder() ([]int, map[int][]int, error) {
+	var selected []int
+	var entries []models.MergePrEntry
+	for i, s := range m.mergeSelected {
+		if s && i < len(m.mergePRs) {
+			selected = append(selected, i)
+			entries = append(entries, m.mergePRs[i])
+		}
+	}
+
+	order, err := release.MergeOrder(entries)
+	if err != nil {
+		return nil, nil, err
+	}
+	queue := make([]int, len(order))
+	for i, o := range order {
+		queue[i] = selected[o]
+	}
+	deps := make(map[int][]int)
+	for i, d := range release.MergeDependencies(entries) {
+		for _, j := range d {
+			deps[selected[i]] = append(deps[selected[i]], selected[j])
+		}
+	}
+	return queue, deps, nil
+}
+
+// planMerge orders the selected PRs and resets per-run merge tracking
+func (m *Model) planMerge() error {
+	queue, deps, err := m.selectedMergeOrder()
+	if err != nil {
+		return err
+	}
+	m.mergeQueue = queue
+	m.mergeDeps = deps
+	m.mergeOutcomes = make(map[int]bool)
+	m.mergeDeploys = make(map[int]*mergeDeploy)
+	m.mergeDeployWaiting = ""
+	return nil
+}
+
+// hasMergeDependents returns true if any queued PR must merge after prIndex
+func (m Model) hasMergeDependents(prIndex int) bool {
+	for _, deps := range m.mergeDeps {
+		for _, d := range deps {
+			if d == prIndex {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// recordMergeOutcome notes whether a PR merged, and starts watching its repo's deploy
+// workflow if other PRs wait on it
+func (m *Model) recordMergeOutcome(prIndex int, merged bool) {
+	m.mergeOutcomes[prIndex] = merged
+	// Auto-merge hands the timing to GitHub, so there's no merge to wait for a deploy after
+	workflow := m.mergePRs[prIndex].Repo.Settings.DeployWorkflow
+	if merged && !m.mergeAuto && workflow != "" && m.hasMergeDependents(prIndex) {
+		m.mergeDeploys[prIndex] = &mergeDeploy{mergedAt: time.Now(), state: release.ChecksNone, detail: "waiting for " + workflow + " to start"}
+	}
+}
+
+// mergeDependencyStatus reports on a PR's dependencies: waiting is set while they are still
+// merging or deploying, failed once one of them can't merge or deploy
+func (m Model) mergeDependencyStatus(prIndex int) (waiting, failed string) {
+	for _, up := range m.mergeDeps[prIndex] {
+		name := m.mergePRs[up].Repo.DisplayName
+		merged, done := m.mergeOutcomes[up]
+		switch {
+		case !done:
+			if waiting == "" {
+				waiting = "after " + name
+			}
+			continue
+		case !merged:
+			return "", name + " didn't merge"
+		}
+
+		d := m.mergeDeploys[up]
+		switch {
+		case d == nil || d.state == release.ChecksPassed:
+		case d.state == release.ChecksFailed:
+			return "", fmt.Sprintf("%s deploy failed: %s", name, d.detail)
+		case waiting == "":
+			waiting = fmt.Sprintf("%s: %s", name, d.detail)
+		}
+	}
+	return waiting, ""
+}
+
+// pollMergeDeploys polls every deploy still being watched (nil if none)
+func (m Model) pollMergeDeploys() tea.Cmd {
+	targets := make(map[int]mergeDeployTarget)
+	for i, d := range m.mergeDeploys {
+		if !d.done() {
+			targets[i] = mergeDeployTarget{pr: m.mergePRs[i], mergedAt: d.mergedAt}
+		}
+	}
+	if len(targets) == 0 {
+		return nil
+	}
+	return pollMergeDeploysCmd(m.gh, targets)
+}
+
+func (m Model) handleMergeDeploysPolled(msg mergeDeploysPolledMsg) (tea.Model, tea.Cmd) {
+	if m.screen != ScreenMerging {
+		return m, nil
+	}
+
+	timeout := m.config.MergeWait.TimeoutOrDefault()
+	for i, check := range msg.deploys {
+		d := m.mergeDeploys[i]
+		if d == nil || d.done() {
+			continue
+		}
+		switch {
+		case check.err != nil:
+			// Keep waiting; the next poll may succeed
+			d.detail = check.err.Error()
+		default:
+			d.state, d.detail = check.state, check.detail
+		}
+		if !d.done() && time.Since(d.mergedAt) > timeout {
+			d.state = release.ChecksFailed
+			d.detail = fmt.Sprintf("timed out after %s", timeout)
+		}
+	}
+
+	// Merge-when-green picks up deploy changes on its next checks poll
+	if m.mergeWait {
+		return m, nil
+	}
+	return m.mergeNext()
+}
+
+// mergeNext merges the next queued PR, skipping PRs whose dependencies failed and
+// waiting while an upstream repo deploys
+func (m Model) mergeNext() (tea.Model, tea.Cmd) {
+	for m.mergeCurrent < len(m.mergeQueue) {
+		prIndex := m.mergeQueue[m.mergeCurrent]
+		waiting, failed := m.mergeDependencyStatus(prIndex)
+		if failed != "" {
+			m.mergeResults = append(m.mergeResults, failedMergeResult(m.mergePRs[prIndex], "skipped: "+failed))
+			m.recordMergeOutcome(prIndex, false)
+			m.mergeCurrent++
+			continue
+		}
+		if waiting != "" {
+			m.mergeDeployWaiting = waiting
+			return m, mergeWaitTickCmd(m.config.MergeWait.PollIntervalOrDefault())
+		}
+		m.mergeDeployWaiting = ""
+		return m, startMergingCmd(&m, prIndex)
+	}
+
+	m.mergeDeployWaiting = ""
+	m.screen = ScreenMergeSummary
+	m.menuIndex = 0
+	return m, nil
+}
+
+// cancelMergeQueue stops waiting on a deploy and skips every PR not yet merged
+func (m Model) cancelMergeQueue() (tea.Model, tea.Cmd) {
+	for ; m.mergeCurrent < len(m.mergeQueue); m.mergeCurrent++ {
+		prIndex := m.mergeQueue[m.mergeCurrent]
+		m.mergeResults = append(m.mergeResults, failedMergeResult(m.mergePRs[prIndex], "cancelled"))
+		m.recordMergeOutcome(prIndex, false)
+	}
+	return m.mergeNext()
+}
+
+// failedMergeResult is the result for a PR that wasn't merged
+func failedMergeResult(pr models.MergePrEntry, reason string) models.MergeResult {
+	return models.MergeResult{
+		RepoName: pr.Repo.DisplayName,
+		PrNumber: pr.PrNumber,
+		PrTitle:  pr.PrTitle,
+		PrType:   pr.PrType,
+		URL:      pr.URL,
+		Error:    &reason,
+	}
+}
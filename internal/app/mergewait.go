@@ -0,0 +1,318 @@
+package app
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/wahlandcase/attuned.prmanager/internal/github"
+	"github.com/wahlandcase/attuned.prmanager/internal/models"
+	"github.com/wahlandcase/attuned.prmanager/internal/release"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// mergeWaitState is where a PR is in wait-for-checks merge mode
+type mergeWaitState int
+
+const (
+	mergeWaitWaiting mergeWaitState = iota
+	mergeWaitChecksRunning
+	mergeWaitMerging
+	mergeWaitMerged
+	mergeWaitFailed
+)
+
+func (s mergeWaitState) String() string {
+	switch s {
+	case mergeWaitWaiting:
+		return "waiting"
+	case mergeWaitChecksRunning:
+		return "checks running"
+	case mergeWaitMerging:
+		return "merging"
+	case mergeWaitMerged:
+		return "merged"
+	default:
+		return "failed"
+	}
+}
+
+// polling returns true while checks are still being watched
+func (s mergeWaitState) polling() bool {
+	return s == mergeWaitWaiting || s == mergeWaitChecksRunning
+}
+
+// mergeWaitEntry tracks one selected PR in wait-for-checks merge mode
+type mergeWaitEntry struct {
+	prIndex int // Index into mergePRs
+	state   mergeWaitState
+	detail  string // Running/failed workflows, or the error
+}
+
+// mergeCheck is the polled check state of one wait entry
+type mergeCheck struct {
+	state  release.CheckState
+	detail string
+	rollup string // GitHub's combined status of the PR's head commit (see GhPr.ChecksState)
+	closed bool   // The PR is no longer open
+	err    error
+}
+
+type mergeChecksPolledMsg struct {
+	checks map[int]mergeCheck // Wait entry index -> checks
+}
+
+type mergeWaitTickMsg struct{}
+
+type mergeWaitMergedMsg struct {
+	entry  int
+	result models.MergeResult
+}
+
+// pollMergeChecksCmd re-reads each waiting PR's head commit and status check rollup, fetches
+// recent workflow runs for its repo (the same runs the actions overview polls), and summarizes
+// the runs for the PR's current head commit
+func pollMergeChecksCmd(client github.Client, prs map[int]models.MergePrEntry) tea.Cmd {
+	return func() tea.Msg {
+		type repoRuns struct {
+			runs []models.WorkflowRun
+			err  error
+		}
+
+		// One request per repo, even if it has several waiting PRs
+		runsByRepo := make(map[string]*repoRuns)
+		var repos []models.RepoInfo
+		for _, pr := range prs {
+			if _, ok := runsByRepo[pr.Repo.Path]; !ok {
+				runsByRepo[pr.Repo.Path] = &repoRuns{}
+				repos = append(repos, pr.Repo)
+			}
+		}
+		var (
+			wg       sync.WaitGroup
+			statuses map[string]models.RepoPrStatus
+			prsErr   error
+		)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			statuses, prsErr = client.ListOpenReleasePRs(repos)
+		}()
+		for path, rr := range runsByRepo {
+			wg.Add(1)
+			go func(path string, rr *repoRuns) {
+				defer wg.Done()
+				rr.runs, rr.err = client.ListWorkflowRuns(path, 20)
+			}(path, rr)
+		}
+		wg.Wait()
+
+		checks := make(map[int]mergeCheck, len(prs))
+		for i, pr := range prs {
+			rr := runsByRepo[pr.Repo.Path]
+			status, ok := statuses[pr.Repo.Path]
+			switch {
+			case prsErr != nil:
+				checks[i] = mergeCheck{err: prsErr}
+				continue
+			case !ok:
+				checks[i] = mergeCheck{err: fmt.Errorf("couldn't refresh PR #%d", pr.PrNumber)}
+				continue
+			case rr.err != nil:
+				checks[i] = mergeCheck{err: rr.err}
+				continue
+			}
+
+			current := findOpenPR(status, pr.PrNumber)
+			if current == nil {
+				checks[i] = mergeCheck{closed: true}
+				continue
+			}
+			state, detail := release.BranchChecks(rr.runs, pr.PrType.HeadBranch(pr.Repo.MainBranch), current.HeadSHA)
+			checks[i] = mergeCheck{state: state, detail: detail, rollup: current.ChecksState}
+		}
+		return mergeChecksPolledMsg{checks: checks}
+	}
+}
+
+// findOpenPR returns the open PR with the given number from a repo's status (nil if it's gone)
+func findOpenPR(status models.RepoPrStatus, number uint64) *models.GhPr {
+	for _, r := range status.PRs {
+		if r.PR != nil && r.PR.Number == number {
+			return r.PR
+		}
+	}
+	return nil
+}
+
+func mergeWaitTickCmd(interval time.Duration) tea.Cmd {
+	return tea.Tick(interval, func(_ time.Time) tea.Msg {
+		return mergeWaitTickMsg{}
+	})
+}
+
+func mergeWaitMergeCmd(client github.Client, entry int, pr models.MergePrEntry) tea.Cmd {
+	return func() tea.Msg {
+		return mergeWaitMergedMsg{entry: entry, result: release.MergePR(client, pr)}
+	}
+}
+
+// startMergeWait begins wait-for-checks mode for the selected PRs, in merge order
+func (m Model) startMergeWait() (tea.Model, tea.Cmd) {
+	m.mergeWaitEntries = nil
+	for _, i := range m.mergeQueue {
+		m.mergeWaitEntries = append(m.mergeWaitEntries, mergeWaitEntry{prIndex: i, state: mergeWaitWaiting})
+	}
+	m.mergeWaitStarted = time.Now()
+	m.mergeResults = nil
+	m.screen = ScreenMerging
+	return m, m.pollMergeChecks()
+}
+
+// pollMergeChecks polls checks for every PR still waiting
+func (m Model) pollMergeChecks() tea.Cmd {
+	prs := make(map[int]models.MergePrEntry)
+	for i, e := range m.mergeWaitEntries {
+		if e.state.polling() {
+			prs[i] = m.mergePRs[e.prIndex]
+		}
+	}
+	return pollMergeChecksCmd(m.gh, prs)
+}
+
+// failMergeWait marks an entry failed and records the result
+func (m *Model) failMergeWait(i int, reason string) {
+	e := &m.mergeWaitEntries[i]
+	e.state = mergeWaitFailed
+	e.detail = reason
+	m.mergeResults = append(m.mergeResults, failedMergeResult(m.mergePRs[e.prIndex], reason))
+	m.recordMergeOutcome(e.prIndex, false)
+}
+
+func (m Model) handleMergeChecksPolled(msg mergeChecksPolledMsg) (tea.Model, tea.Cmd) {
+	if m.screen != ScreenMerging || !m.mergeWait {
+		return m, nil
+	}
+
+	var cmds []tea.Cmd
+	for i, check := range msg.checks {
+		if i >= len(m.mergeWaitEntries) || !m.mergeWaitEntries[i].state.polling() {
+			continue // Cancelled meanwhile
+		}
+		e := &m.mergeWaitEntries[i]
+		pr := m.mergePRs[e.prIndex]
+		waiting, failed := m.mergeDependencyStatus(e.prIndex)
+
+		switch {
+		case failed != "":
+			m.failMergeWait(i, "skipped: "+failed)
+		case check.err != nil:
+			// Keep waiting; the next poll may succeed
+			e.detail = check.err.Error()
+		case check.closed:
+			m.failMergeWait(i, "PR is no longer open")
+		case check.state == release.ChecksFailed:
+			m.failMergeWait(i, "checks failed: "+check.detail)
+		case check.rollup == "FAILURE" || check.rollup == "ERROR":
+			m.failMergeWait(i, "checks failed")
+		case check.state == release.ChecksRunning:
+			e.state = mergeWaitChecksRunning
+			e.detail = check.detail
+		case check.rollup != "" && check.rollup != "SUCCESS":
+			// No runs for the head commit yet, or checks from outside Actions are still pending
+			e.detail = "waiting for checks to start"
+		case waiting != "":
+			// Green, but an upstream repo hasn't merged or deployed yet
+			e.detail = waiting
+		default:
+			// Every run for the head commit passed and GitHub's rollup agrees (or it has no checks)
+			e.state = mergeWaitMerging
+			e.detail = ""
+			cmds = append(cmds, mergeWaitMergeCmd(m.gh, i, pr))
+		}
+	}
+
+	// Give up on anything still waiting past the timeout
+	timeout := m.config.MergeWait.TimeoutOrDefault()
+	if time.Since(m.mergeWaitStarted) > timeout {
+		for i, e := range m.mergeWaitEntries {
+			if e.state.polling() {
+				m.failMergeWait(i, fmt.Sprintf("timed out after %s", timeout))
+			}
+		}
+	}
+
+	if m.mergeWaitPolling() {
+		cmds = append(cmds, mergeWaitTickCmd(m.config.MergeWait.PollIntervalOrDefault()))
+	}
+	return m.finishMergeWaitIfDone(cmds)
+}
+
+func (m Model) handleMergeWaitTick() (tea.Model, tea.Cmd) {
+	if m.screen != ScreenMerging {
+		return m, nil
+	}
+	if !m.mergeWait {
+		// Immediate merges only tick while the next PR waits on an upstream deploy
+		if m.mergeDeployWaiting == "" {
+			return m, nil
+		}
+		if cmd := m.pollMergeDeploys(); cmd != nil {
+			return m, cmd
+		}
+		return m.mergeNext()
+	}
+	if !m.mergeWaitPolling() {
+		return m, nil
+	}
+	return m, tea.Batch(m.pollMergeChecks(), m.pollMergeDeploys())
+}
+
+func (m Model) handleMergeWaitMerged(msg mergeWaitMergedMsg) (tea.Model, tea.Cmd) {
+	if msg.entry >= len(m.mergeWaitEntries) {
+		return m, nil
+	}
+	e := &m.mergeWaitEntries[msg.entry]
+	m.recordMergeOutcome(e.prIndex, msg.result.Success)
+	if msg.result.Success {
+		e.state = mergeWaitMerged
+	} else {
+		e.state = mergeWaitFailed
+		e.detail = *msg.result.Error
+	}
+	m.mergeResults = append(m.mergeResults, msg.result)
+	return m.finishMergeWaitIfDone(nil)
+}
+
+// cancelMergeWait stops watching checks; PRs already merging are allowed to finish
+func (m Model) cancelMergeWait() (tea.Model, tea.Cmd) {
+	for i, e := range m.mergeWaitEntries {
+		if e.state.polling() {
+			m.failMergeWait(i, "cancelled")
+		}
+	}
+	return m.finishMergeWaitIfDone(nil)
+}
+
+// mergeWaitPolling returns true if any PR is still waiting for checks
+func (m Model) mergeWaitPolling() bool {
+	for _, e := range m.mergeWaitEntries {
+		if e.state.polling() {
+			return true
+		}
+	}
+	return false
+}
+
+// finishMergeWaitIfDone moves to the summary once every PR has merged or failed
+func (m Model) finishMergeWaitIfDone(cmds []tea.Cmd) (tea.Model, tea.Cmd) {
+	for _, e := range m.mergeWaitEntries {
+		if e.state != mergeWaitMerged && e.state != mergeWaitFailed {
+			return m, tea.Batch(cmds...)
+		}
+	}
+	m.screen = ScreenMergeSummary
+	m.menuIndex = 0
+	return m, tea.Batch(cmds...)
+}
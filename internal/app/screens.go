@@ -27,6 +27,7 @@ const (
 	ScreenPullBranchSelect
 	ScreenPullProgress
 	ScreenPullSummary
+	ScreenPullResetConfirmation
 	ScreenActionsOverview
 )
 
@@ -55,6 +56,7 @@ func (s Screen) String() string {
 		"PullBranchSelect",
 		"PullProgress",
 		"PullSummary",
+		"PullResetConfirmation",
 		"ActionsOverview",
 	}
 	if int(s) < len(names) {
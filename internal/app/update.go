@@ -2,9 +2,11 @@ package app
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/wahlandcase/attuned.prmanager/internal/config"
 	"github.com/wahlandcase/attuned.prmanager/internal/models"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -61,7 +63,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m.handleBatchRepoResult(msg)
 
 	case batchProgressMsg:
-		m.batchCurrentStep = msg.step
+		// Ignore late steps from repos that already finished
+		if _, ok := m.batchInFlight[msg.repoIndex]; ok {
+			m.batchInFlight[msg.repoIndex] = msg.step
+		}
 		// Continue listening for more progress updates
 		return m, listenForProgress(m.batchProgressChan)
 
@@ -71,6 +76,18 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case mergeCompleteResult:
 		return m.handleMergeCompleteResult(msg)
 
+	case mergeChecksPolledMsg:
+		return m.handleMergeChecksPolled(msg)
+
+	case mergeWaitTickMsg:
+		return m.handleMergeWaitTick()
+
+	case mergeWaitMergedMsg:
+		return m.handleMergeWaitMerged(msg)
+
+	case mergeDeploysPolledMsg:
+		return m.handleMergeDeploysPolled(msg)
+
 	case batchReposLoadedResult:
 		return m.handleBatchReposLoaded(msg)
 
@@ -93,6 +110,13 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case pullReposLoadedResult:
 		return m.handlePullReposLoaded(msg)
 
+	case pullResetDoneMsg:
+		for i, r := range msg.results {
+			m.pullResults[i] = r
+		}
+		m.screen = ScreenPullSummary
+		return m, nil
+
 	case pullRepoResult:
 		return m.handlePullRepoResult(msg)
 
@@ -129,7 +153,7 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.handleCommitReviewKey(msg)
 	case ScreenTitleInput:
 		return m.handleTitleInputKey(msg)
-	case ScreenConfirmation, ScreenBatchConfirmation, ScreenMergeConfirmation:
+	case ScreenConfirmation, ScreenBatchConfirmation, ScreenMergeConfirmation, ScreenPullResetConfirmation:
 		return m.handleConfirmationKey(msg)
 	case ScreenComplete:
 		return m.handleCompleteKey(msg)
@@ -141,6 +165,8 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.handleBatchSummaryKey(msg)
 	case ScreenViewOpenPrs:
 		return m.handleViewOpenPrsKey(msg)
+	case ScreenMerging:
+		return m.handleMergingKey(msg)
 	case ScreenMergeSummary:
 		return m.handleMergeSummaryKey(msg)
 	case ScreenUpdatePrompt:
@@ -203,6 +229,8 @@ func (m Model) handleMainMenuKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		// Pull all repos
 		m.screen = ScreenPullBranchSelect
 		m.menuIndex = 0
+		m.pullAutoStash = m.config.Pull.AutoStash
+		m.pullMode = m.config.Pull.ModeOrDefault()
 	}
 	return m, nil
 }
@@ -221,7 +249,7 @@ func (m Model) selectMainMenuItem() (tea.Model, tea.Cmd) {
 		m.mode = &mode
 		m.screen = ScreenLoading
 		m.loadingMessage = "Detecting repository..."
-		return m, loadCurrentRepoCmd()
+		return m, loadCurrentRepoCmd(m.config)
 	case 1: // Batch Mode
 		mode := ModeBatch
 		m.mode = &mode
@@ -233,7 +261,7 @@ func (m Model) selectMainMenuItem() (tea.Model, tea.Cmd) {
 		m.actionsLoading = true
 		m.screen = ScreenLoading
 		m.loadingMessage = "Fetching workflow runs..."
-		return m, fetchActionsRunsCmd(m.config, m.dryRun)
+		return m, fetchActionsRunsCmd(m.gh, m.config, m.dryRun)
 	case 4: // Quit
 		m.shouldQuit = true
 		return m, tea.Quit
@@ -250,17 +278,19 @@ func (m Model) handlePrTypeSelectKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		if m.menuIndex > 0 {
 			m.menuIndex--
 		} else {
-			m.menuIndex = 1
+			m.menuIndex = len(m.prTypeOptions()) - 1
 		}
 	case "down", "j":
-		if m.menuIndex < 1 {
+		if m.menuIndex < len(m.prTypeOptions())-1 {
 			m.menuIndex++
 		} else {
 			m.menuIndex = 0
 		}
-	case "enter", "1", "2":
-		if idx, ok := numKeyIndex(msg.String(), 2); ok {
+	case "enter", "1", "2", "3", "4", "5", "6", "7", "8", "9":
+		if idx, ok := numKeyIndex(msg.String(), len(m.prTypeOptions())); ok {
 			m.menuIndex = idx
+		} else if msg.String() != "enter" {
+			return m, nil
 		}
 		return m.selectPrType()
 	case "esc":
@@ -272,7 +302,10 @@ func (m Model) handlePrTypeSelectKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 }
 
 func (m Model) selectPrType() (tea.Model, tea.Cmd) {
-	prTypes := []models.PrType{models.DevToStaging, models.StagingToMain}
+	prTypes := m.prTypeOptions()
+	if m.menuIndex >= len(prTypes) {
+		return m, nil
+	}
 	prType := prTypes[m.menuIndex]
 	m.prType = &prType
 
@@ -288,7 +321,7 @@ func (m Model) selectPrType() (tea.Model, tea.Cmd) {
 	// Single mode - start fetching commits
 	m.screen = ScreenLoading
 	m.loadingMessage = "Fetching branches and commits..."
-	return m, fetchCommitsCmd(m.repoInfo, m.prType, m.config.TicketRegex(), m.dryRun)
+	return m, fetchCommitsCmd(m.gh, m.repoInfo, m.prType, m.config.TicketRegex(), m.dryRun)
 }
 
 func (m Model) handleCommitReviewKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
@@ -317,6 +350,8 @@ func (m Model) handleCommitReviewKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.commits = nil
 		m.tickets = nil
 		m.menuIndex = 0
+	case tea.KeyTab:
+		m.prChangelog = !m.prChangelog
 	case tea.KeyBackspace:
 		if len(m.prTitle) > 0 {
 			m.prTitle = m.prTitle[:len(m.prTitle)-1]
@@ -382,6 +417,13 @@ func (m Model) handleConfirmationKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		if m.screen == ScreenBatchConfirmation {
 			m.scrollBatchConfirm(1)
 		}
+	case "f":
+		if m.screen == ScreenMergeConfirmation {
+			m.toggleMergeOverride()
+			if m.mergeSelectedCount() == 0 {
+				return m.goBack()
+			}
+		}
 	case "y":
 		m.confirmSelection = 0
 		return m.confirmAction()
@@ -401,49 +443,53 @@ func (m Model) handleConfirmationKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 func (m Model) confirmAction() (tea.Model, tea.Cmd) {
 	switch m.screen {
 	case ScreenConfirmation:
+		title, body, err := m.renderPR()
+		if err != nil {
+			m.errorMessage = "PR template: " + err.Error()
+			m.screen = ScreenError
+			return m, nil
+		}
 		m.screen = ScreenCreating
-		return m, createPRCmd(m.repoInfo, m.prType, m.prTitle, m.tickets, m.config.Tickets.LinearOrg, m.dryRun)
+		return m, createPRCmd(m.gh, m.repoInfo, m.prType, title, body)
 	case ScreenBatchConfirmation:
 		// Block if no repos have commits
 		if m.batchReposWithCommits == 0 {
 			return m, nil
 		}
-		// Count selected repos
-		m.batchTotal = 0
-		for _, selected := range m.batchSelected {
-			if selected {
-				m.batchTotal++
+		// Queue selected repos
+		m.batchQueue = nil
+		for i, selected := range m.batchSelected {
+			if selected && i < len(m.batchRepos) {
+				m.batchQueue = append(m.batchQueue, i)
 			}
 		}
-		m.batchCurrent = 0
+		m.batchTotal = len(m.batchQueue)
+		m.batchInFlight = make(map[int]string)
 		m.batchResults = nil
-		m.batchCurrentRepo = m.batchRepos[0].DisplayName
-		m.batchCurrentStep = ""
-		// Create progress channel for real-time updates
-		m.batchProgressChan = make(chan string, 1)
+		// Create progress channel for real-time updates (room for a few steps per worker)
+		m.batchProgressChan = make(chan batchProgressMsg, 4*m.config.Concurrency.BatchOrDefault())
 		m.screen = ScreenBatchProcessing
-		// Start both the processing command and the progress listener
+		// Start the first workers and the progress listener
 		return m, tea.Batch(
-			startBatchProcessingCmd(&m, 0),
+			m.startNextBatchRepos(),
 			listenForProgress(m.batchProgressChan),
 		)
 	case ScreenMergeConfirmation:
-		// Count selected PRs
-		m.mergeTotal = 0
-		for _, selected := range m.mergeSelected {
-			if selected {
-				m.mergeTotal++
-			}
+		if err := m.planMerge(); err != nil {
+			m.copyFeedback = "✗ " + err.Error()
+			return m, nil
+		}
+		if m.mergeWait {
+			return m.startMergeWait()
 		}
 		m.mergeCurrent = 0
 		m.mergeResults = nil
 		m.screen = ScreenMerging
-		// Find first selected PR
-		for i, selected := range m.mergeSelected {
-			if selected {
-				return m, startMergingCmd(&m, i)
-			}
-		}
+		return m.mergeNext()
+	case ScreenPullResetConfirmation:
+		m.screen = ScreenLoading
+		m.loadingMessage = "Resetting branches to origin..."
+		return m, resetToOriginCmd(m.pullResults, m.dryRun)
 	}
 	return m, nil
 }
@@ -456,6 +502,8 @@ func (m Model) goBack() (tea.Model, tea.Cmd) {
 		m.screen = ScreenTitleInput // batch mode still uses separate title input
 	case ScreenMergeConfirmation:
 		m.screen = ScreenViewOpenPrs
+	case ScreenPullResetConfirmation:
+		m.screen = ScreenPullSummary
 	}
 	m.confirmSelection = 0
 	return m, nil
@@ -489,6 +537,17 @@ func (m Model) handleCompleteKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// pullResettableCount counts repos whose branch could be reset to origin
+func (m Model) pullResettableCount() int {
+	n := 0
+	for _, r := range m.pullResults {
+		if r.CanResetToOrigin() {
+			n++
+		}
+	}
+	return n
+}
+
 func (m Model) handleErrorKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "enter", "esc", "q":
@@ -511,27 +570,9 @@ func (m Model) handleBatchRepoSelectKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case tea.KeyDown:
 		m.navigateBatchColumn(false)
 	case tea.KeyLeft:
-		if m.batchColumn != 0 {
-			filtered := m.getFilteredBatchRepos(0)
-			if len(filtered) > 0 {
-				m.batchColumn = 0
-				// Clamp index to valid range
-				if m.batchFEIndex >= len(filtered) {
-					m.batchFEIndex = len(filtered) - 1
-				}
-			}
-		}
+		m.moveBatchColumn(-1)
 	case tea.KeyRight:
-		if m.batchColumn != 1 {
-			filtered := m.getFilteredBatchRepos(1)
-			if len(filtered) > 0 {
-				m.batchColumn = 1
-				// Clamp index to valid range
-				if m.batchBEIndex >= len(filtered) {
-					m.batchBEIndex = len(filtered) - 1
-				}
-			}
-		}
+		m.moveBatchColumn(1)
 	case tea.KeySpace:
 		m.toggleBatchSelection()
 	case tea.KeyTab, tea.KeyEnter:
@@ -566,7 +607,7 @@ func (m Model) handleBatchRepoSelectKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		// Go to loading screen to check for existing PRs (commits already cached)
 		m.screen = ScreenLoading
 		m.loadingMessage = "Checking for existing PRs..."
-		return m, fetchBatchCommitsCmd(m.batchRepos, m.batchSelected, m.batchRepoCommits, m.prType, m.dryRun)
+		return m, fetchBatchCommitsCmd(m.gh, m.batchRepos, m.batchSelected, m.batchRepoCommits, m.prType)
 	case tea.KeyEsc:
 		// Cancel background fetches and close channel
 		m.cancelBatchFetch()
@@ -576,8 +617,7 @@ func (m Model) handleBatchRepoSelectKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case tea.KeyBackspace:
 		if len(m.batchFilter) > 0 {
 			m.batchFilter = m.batchFilter[:len(m.batchFilter)-1]
-			m.batchFEIndex = 0
-			m.batchBEIndex = 0
+			m.resetBatchColumnIndexes()
 		}
 	case tea.KeyCtrlC:
 		m.shouldQuit = true
@@ -585,19 +625,21 @@ func (m Model) handleBatchRepoSelectKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case tea.KeyRunes:
 		// Type to filter - all printable characters go to filter
 		m.batchFilter += string(msg.Runes)
-		m.batchFEIndex = 0
-		m.batchBEIndex = 0
+		m.resetBatchColumnIndexes()
 	}
 	return m, nil
 }
 
-// getFilteredBatchRepos returns indices of repos matching the current filter for the given column (0=frontend, 1=backend)
+// getFilteredBatchRepos returns indices of repos matching the current filter for the given column (index into batchGroups)
 func (m *Model) getFilteredBatchRepos(column int) []int {
 	var indices []int
 	filter := strings.ToLower(m.batchFilter)
+	if column < 0 || column >= len(m.batchGroups) {
+		return nil
+	}
 
 	for i, repo := range m.batchRepos {
-		if !repo.InColumn(column) {
+		if repo.Group != m.batchGroups[column] {
 			continue
 		}
 		if filter != "" && !strings.Contains(strings.ToLower(repo.DisplayName), filter) {
@@ -629,13 +671,42 @@ func navigateColumnIndex(idx *int, listLen int, up bool) {
 	}
 }
 
+// batchRowIndex returns a pointer to the selected row of the current batch column
+func (m *Model) batchRowIndex() *int {
+	if len(m.batchColumnIndex) != len(m.batchGroups) {
+		m.batchColumnIndex = make([]int, len(m.batchGroups))
+	}
+	if m.batchColumn >= len(m.batchColumnIndex) {
+		var none int
+		return &none
+	}
+	return &m.batchColumnIndex[m.batchColumn]
+}
+
+// resetBatchColumnIndexes moves every column back to its first row (after the filter changes)
+func (m *Model) resetBatchColumnIndexes() {
+	m.batchColumnIndex = make([]int, len(m.batchGroups))
+}
+
+// moveBatchColumn moves to the nearest non-empty column in the given direction (-1 left, 1 right)
+func (m *Model) moveBatchColumn(dir int) {
+	for col := m.batchColumn + dir; col >= 0 && col < len(m.batchGroups); col += dir {
+		filtered := m.getFilteredBatchRepos(col)
+		if len(filtered) == 0 {
+			continue
+		}
+		m.batchColumn = col
+		// Clamp index to valid range
+		if idx := m.batchRowIndex(); *idx >= len(filtered) {
+			*idx = len(filtered) - 1
+		}
+		return
+	}
+}
+
 func (m *Model) navigateBatchColumn(up bool) {
 	filtered := m.getFilteredBatchRepos(m.batchColumn)
-	if m.batchColumn == 0 {
-		navigateColumnIndex(&m.batchFEIndex, len(filtered), up)
-	} else {
-		navigateColumnIndex(&m.batchBEIndex, len(filtered), up)
-	}
+	navigateColumnIndex(m.batchRowIndex(), len(filtered), up)
 }
 
 // toggleSelection toggles a boolean in a selection slice at the index pointed to by the current column position
@@ -651,11 +722,7 @@ func toggleSelection(selected []bool, filtered []int, currentIdx int) {
 
 func (m *Model) toggleBatchSelection() {
 	filtered := m.getFilteredBatchRepos(m.batchColumn)
-	currentIdx := m.batchFEIndex
-	if m.batchColumn == 1 {
-		currentIdx = m.batchBEIndex
-	}
-	toggleSelection(m.batchSelected, filtered, currentIdx)
+	toggleSelection(m.batchSelected, filtered, *m.batchRowIndex())
 }
 
 func (m Model) handleBatchSummaryKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
@@ -707,38 +774,14 @@ func (m Model) handleViewOpenPrsKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case tea.KeyDown:
 		m.navigateMergeColumn(false)
 	case tea.KeyLeft:
-		if m.mergeColumn != 0 {
-			filtered := m.getFilteredMergePRs(0)
-			if len(filtered) > 0 {
-				m.mergeColumn = 0
-				// Clamp index to valid range
-				if m.mergeDevIndex >= len(filtered) {
-					m.mergeDevIndex = len(filtered) - 1
-				}
-			}
-		}
+		m.moveMergeColumn(-1)
 	case tea.KeyRight:
-		if m.mergeColumn != 1 {
-			filtered := m.getFilteredMergePRs(1)
-			if len(filtered) > 0 {
-				m.mergeColumn = 1
-				// Clamp index to valid range
-				if m.mergeMainIndex >= len(filtered) {
-					m.mergeMainIndex = len(filtered) - 1
-				}
-			}
-		}
+		m.moveMergeColumn(1)
 	case tea.KeySpace:
 		m.toggleMergeSelection()
 	case tea.KeyTab, tea.KeyEnter:
 		// Proceed to merge confirmation if any selected
-		count := 0
-		for _, selected := range m.mergeSelected {
-			if selected {
-				count++
-			}
-		}
-		if count > 0 {
+		if m.mergeSelectedCount() > 0 {
 			m.screen = ScreenMergeConfirmation
 			m.confirmSelection = 0
 		}
@@ -758,10 +801,16 @@ func (m Model) handleViewOpenPrsKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			return m, tea.Quit
 		case "a":
 			m.selectAllInColumn()
+		case "f":
+			m.toggleMergeOverride()
+		case "u":
+			m.toggleMergeAuto()
+		case "w":
+			m.toggleMergeWait()
 		case "r":
 			m.screen = ScreenLoading
 			m.loadingMessage = "Fetching open PRs..."
-			return m, fetchOpenPRsCmd(m.config, m.dryRun)
+			return m, fetchOpenPRsCmd(m.gh, m.config, m.dryRun)
 		case "o":
 			// Open all PR URLs
 			var urls []string
@@ -784,42 +833,130 @@ func (m Model) handleViewOpenPrsKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
-// getFilteredMergePRs returns indices of PRs for the given column (0=dev->staging, 1=staging->main)
-func (m *Model) getFilteredMergePRs(column int) []int {
-	var indices []int
+// mergeSelectedCount returns the number of PRs selected for merging
+func (m *Model) mergeSelectedCount() int {
+	count := 0
+	for _, selected := range m.mergeSelected {
+		if selected {
+			count++
+		}
+	}
+	return count
+}
 
-	var targetType models.PrType
-	if column == 0 {
-		targetType = models.DevToStaging
-	} else {
-		targetType = models.StagingToMain
+// mergeColumnCount returns the number of open PR columns (one per promotion in the global flow)
+func (m *Model) mergeColumnCount() int {
+	return len(m.config.ReleaseFlow().PrTypes())
+}
+
+// mergeColumnOf returns the column a PR belongs to. PRs from repos with their own flow are
+// matched onto the global flow; anything unmatched lands in the last (production) column.
+func (m *Model) mergeColumnOf(pr models.MergePrEntry) int {
+	if t, ok := m.config.ReleaseFlow().Resolve(pr.PrType); ok {
+		return t.Index
 	}
+	return m.mergeColumnCount() - 1
+}
 
+// getFilteredMergePRs returns indices of PRs for the given column (promotion index in the global flow)
+func (m *Model) getFilteredMergePRs(column int) []int {
+	var indices []int
 	for i, pr := range m.mergePRs {
-		if pr.PrType == targetType {
+		if m.mergeColumnOf(pr) == column {
 			indices = append(indices, i)
 		}
 	}
-
 	return indices
 }
 
+// mergeRowIndex returns a pointer to the selected row of the current column
+func (m *Model) mergeRowIndex() *int {
+	if len(m.mergeColumnIndex) != m.mergeColumnCount() {
+		m.mergeColumnIndex = make([]int, m.mergeColumnCount())
+	}
+	return &m.mergeColumnIndex[m.mergeColumn]
+}
+
+// moveMergeColumn moves to the nearest non-empty column in the given direction (-1 left, 1 right)
+func (m *Model) moveMergeColumn(dir int) {
+	for col := m.mergeColumn + dir; col >= 0 && col < m.mergeColumnCount(); col += dir {
+		filtered := m.getFilteredMergePRs(col)
+		if len(filtered) == 0 {
+			continue
+		}
+		m.mergeColumn = col
+		// Clamp index to valid range
+		if idx := m.mergeRowIndex(); *idx >= len(filtered) {
+			*idx = len(filtered) - 1
+		}
+		return
+	}
+}
+
 func (m *Model) navigateMergeColumn(up bool) {
 	filtered := m.getFilteredMergePRs(m.mergeColumn)
-	if m.mergeColumn == 0 {
-		navigateColumnIndex(&m.mergeDevIndex, len(filtered), up)
-	} else {
-		navigateColumnIndex(&m.mergeMainIndex, len(filtered), up)
-	}
+	navigateColumnIndex(m.mergeRowIndex(), len(filtered), up)
 }
 
 func (m *Model) toggleMergeSelection() {
 	filtered := m.getFilteredMergePRs(m.mergeColumn)
-	currentIdx := m.mergeDevIndex
-	if m.mergeColumn == 1 {
-		currentIdx = m.mergeMainIndex
+	idx := *m.mergeRowIndex()
+	if idx >= len(filtered) {
+		return
+	}
+	prIdx := filtered[idx]
+	if prIdx < len(m.mergeSelected) && !m.mergeSelected[prIdx] && !m.mergeAllowed(m.mergePRs[prIdx]) {
+		m.copyFeedback = fmt.Sprintf("✗ Blocked: %s (f to override)", strings.Join(m.mergeBlockers(m.mergePRs[prIdx]), ", "))
+		return
+	}
+	toggleSelection(m.mergeSelected, filtered, idx)
+}
+
+// mergeBlockers returns what keeps a PR from being selected in the current mode
+func (m *Model) mergeBlockers(pr models.MergePrEntry) []string {
+	switch {
+	case m.mergeAuto:
+		return pr.AutoMergeBlockers()
+	case m.mergeWait:
+		return pr.MergeBlockersExcept(models.BlockerChecksPending)
+	}
+	return pr.MergeBlockers()
+}
+
+// mergeAllowed returns true if the PR can be selected for merging
+func (m *Model) mergeAllowed(pr models.MergePrEntry) bool {
+	return m.mergeOverride || len(m.mergeBlockers(pr)) == 0
+}
+
+// toggleMergeOverride switches the merge gate override, deselecting blocked PRs when it's turned off
+func (m *Model) toggleMergeOverride() {
+	m.mergeOverride = !m.mergeOverride
+	m.deselectBlockedMerges()
+}
+
+// toggleMergeAuto switches between merging now and enabling auto-merge, which lets PRs
+// with pending checks or reviews be selected
+func (m *Model) toggleMergeAuto() {
+	m.mergeAuto = !m.mergeAuto
+	m.mergeWait = false
+	m.deselectBlockedMerges()
+}
+
+// toggleMergeWait switches between merging now and waiting for each PR's checks to pass,
+// which lets PRs with pending checks be selected
+func (m *Model) toggleMergeWait() {
+	m.mergeWait = !m.mergeWait
+	m.mergeAuto = false
+	m.deselectBlockedMerges()
+}
+
+// deselectBlockedMerges deselects PRs that are no longer allowed
+func (m *Model) deselectBlockedMerges() {
+	for i, pr := range m.mergePRs {
+		if i < len(m.mergeSelected) && !m.mergeAllowed(pr) {
+			m.mergeSelected[i] = false
+		}
 	}
-	toggleSelection(m.mergeSelected, filtered, currentIdx)
 }
 
 func (m *Model) selectAllInColumn() {
@@ -828,24 +965,38 @@ func (m *Model) selectAllInColumn() {
 		return
 	}
 
-	// Check if all in column are selected
+	// Check if all selectable PRs in column are selected
 	allSelected := true
 	for _, prIdx := range filtered {
-		if prIdx < len(m.mergeSelected) && !m.mergeSelected[prIdx] {
+		if prIdx < len(m.mergeSelected) && !m.mergeSelected[prIdx] && m.mergeAllowed(m.mergePRs[prIdx]) {
 			allSelected = false
 			break
 		}
 	}
 
-	// Toggle: if all selected, deselect all; otherwise select all
+	// Toggle: if all selected, deselect all; otherwise select all (skipping blocked PRs)
 	newState := !allSelected
 	for _, prIdx := range filtered {
-		if prIdx < len(m.mergeSelected) {
+		if prIdx < len(m.mergeSelected) && (!newState || m.mergeAllowed(m.mergePRs[prIdx])) {
 			m.mergeSelected[prIdx] = newState
 		}
 	}
 }
 
+func (m Model) handleMergingKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		// Only waits can be cancelled; immediate merges are already in flight
+		if m.mergeWait {
+			return m.cancelMergeWait()
+		}
+		if m.mergeDeployWaiting != "" {
+			return m.cancelMergeQueue()
+		}
+	}
+	return m, nil
+}
+
 func (m Model) handleMergeSummaryKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "q":
@@ -1089,7 +1240,7 @@ func (m Model) handleActionsOverviewKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			Run:  entry.Run,
 			Repo: entry.Repo,
 		})
-		return m, fetchActionsJobsCmd(entry.Repo.Path, entry.Run.DatabaseID, m.dryRun)
+		return m, fetchActionsJobsCmd(m.gh, entry.Repo.Path, entry.Run.DatabaseID)
 	case tea.KeyEsc:
 		if m.actionsFilterActive {
 			m.actionsFilterActive = false
@@ -1133,7 +1284,7 @@ func (m Model) handleActionsOverviewKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 					Run:  entry.Run,
 					Repo: entry.Repo,
 				})
-				cmds = append(cmds, fetchActionsJobsCmd(entry.Repo.Path, entry.Run.DatabaseID, m.dryRun))
+				cmds = append(cmds, fetchActionsJobsCmd(m.gh, entry.Repo.Path, entry.Run.DatabaseID))
 			}
 			if len(cmds) > 0 {
 				return m, tea.Batch(cmds...)
@@ -1184,6 +1335,7 @@ func (m Model) reset() (tea.Model, tea.Cmd) {
 	m.tickets = nil
 	m.prTitle = ""
 	m.prURL = ""
+	m.prChangelog = m.config.Changelog.Enabled
 	m.batchRepos = nil
 	m.batchRepoCommits = nil
 	m.batchFetchPending = 0
@@ -1202,10 +1354,11 @@ func (m Model) reset() (tea.Model, tea.Cmd) {
 	m.confetti = nil
 	m.typewriterPos = 0
 	// Reset pull state
-	m.pullBranch = ""
+	m.pullStage = 0
 	m.pullRepos = nil
 	m.pullResults = nil
-	m.pullCurrentIdx = 0
+	m.pullQueue = nil
+	m.pullInFlight = nil
 	// Reset actions state
 	m.actionsEntries = nil
 	m.actionsIndex = 0
@@ -1226,7 +1379,7 @@ func (m Model) navigateToMergePRs() (tea.Model, tea.Cmd) {
 	m.mode = &mode
 	m.screen = ScreenLoading
 	m.loadingMessage = "Fetching open PRs..."
-	return m, fetchOpenPRsCmd(m.config, m.dryRun)
+	return m, fetchOpenPRsCmd(m.gh, m.config, m.dryRun)
 }
 
 func (m Model) handlePullBranchSelectKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
@@ -1238,19 +1391,27 @@ func (m Model) handlePullBranchSelectKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		if m.menuIndex > 0 {
 			m.menuIndex--
 		} else {
-			m.menuIndex = 2 // Wrap to bottom (3 options: dev, staging, main)
+			m.menuIndex = len(m.config.ReleaseFlow().Stages) - 1 // Wrap to bottom (one option per stage)
 		}
 	case "down", "j":
-		if m.menuIndex < 2 {
+		if m.menuIndex < len(m.config.ReleaseFlow().Stages)-1 {
 			m.menuIndex++
 		} else {
 			m.menuIndex = 0 // Wrap to top
 		}
-	case "enter", "1", "2", "3":
-		if idx, ok := numKeyIndex(msg.String(), 3); ok {
+	case "enter", "1", "2", "3", "4", "5", "6", "7", "8", "9":
+		if idx, ok := numKeyIndex(msg.String(), len(m.config.ReleaseFlow().Stages)); ok {
 			m.menuIndex = idx
+		} else if msg.String() != "enter" {
+			return m, nil
 		}
 		return m.selectPullBranch()
+	case "s":
+		m.pullAutoStash = !m.pullAutoStash
+	case "b":
+		// Cycle checkout -> update -> restore
+		next := (slices.Index(config.PullModes, m.pullMode) + 1) % len(config.PullModes)
+		m.pullMode = config.PullModes[next]
 	case "esc":
 		m.screen = ScreenMainMenu
 		m.menuIndex = 0
@@ -1259,10 +1420,9 @@ func (m Model) handlePullBranchSelectKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 }
 
 func (m Model) selectPullBranch() (tea.Model, tea.Cmd) {
-	branches := []string{"dev", "staging", "main"}
-	m.pullBranch = branches[m.menuIndex]
+	m.pullStage = m.menuIndex
 	m.screen = ScreenLoading
-	m.loadingMessage = fmt.Sprintf("Scanning repositories for %s...", m.pullBranch)
+	m.loadingMessage = fmt.Sprintf("Scanning repositories for %s...", m.pullBranch())
 	return m, loadPullReposCmd(m.config)
 }
 
@@ -1273,6 +1433,12 @@ func (m Model) handlePullSummaryKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, tea.Quit
 	case "enter", "esc":
 		return m.reset()
+	case "r":
+		// Offer to reset branches with local-only commits
+		if m.pullResettableCount() > 0 {
+			m.screen = ScreenPullResetConfirmation
+			m.confirmSelection = 1 // Default to No: this drops commits
+		}
 	}
 	return m, nil
 }
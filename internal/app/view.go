@@ -3,10 +3,12 @@ package app
 import (
 	"fmt"
 	"math"
+	"sort"
 	"strings"
 	"time"
 	"unicode/utf8"
 
+	"github.com/wahlandcase/attuned.prmanager/internal/config"
 	"github.com/wahlandcase/attuned.prmanager/internal/models"
 	"github.com/wahlandcase/attuned.prmanager/internal/ui"
 	"github.com/wahlandcase/attuned.prmanager/internal/update"
@@ -133,6 +135,8 @@ func (m Model) renderContentWithHeight(availableHeight int) string {
 		return m.renderPullProgress()
 	case ScreenPullSummary:
 		return m.renderPullSummaryWithHeight(availableHeight)
+	case ScreenPullResetConfirmation:
+		return m.renderPullResetConfirmation()
 	case ScreenActionsOverview:
 		return m.renderActionsOverviewWithHeight(availableHeight)
 	default:
@@ -181,16 +185,27 @@ func (m Model) renderPrTypeSelect() string {
 	var menuLines []string
 	menuLines = append(menuLines, "")
 
-	types := []struct {
+	prTypes := m.prTypeOptions()
+	stageCount := len(prTypes) + 1
+
+	type typeOption struct {
 		num       string
 		head      string
 		base      string
 		desc      string
 		headColor lipgloss.Color
 		baseColor lipgloss.Color
-	}{
-		{"1.", "dev", "staging", "Merge to staging for QA", ui.ColorGreen, ui.ColorYellow},
-		{"2.", "staging", mainBranch, "Release to production", ui.ColorYellow, ui.ColorRed},
+	}
+	var types []typeOption
+	for i, pt := range prTypes {
+		types = append(types, typeOption{
+			num:       fmt.Sprintf("%d.", i+1),
+			head:      pt.HeadBranch(mainBranch),
+			base:      pt.BaseBranch(mainBranch),
+			desc:      prTypeDescription(pt, mainBranch),
+			headColor: ui.StageColor(pt.Index, stageCount),
+			baseColor: ui.StageColor(pt.Index+1, stageCount),
+		})
 	}
 
 	for i, t := range types {
@@ -244,30 +259,24 @@ func (m Model) renderPrTypeSelect() string {
 	var infoLines []string
 	infoLines = append(infoLines, "")
 
-	if m.menuIndex == 0 {
-		greenStyle := lipgloss.NewStyle().Foreground(ui.ColorGreen).Bold(true)
-		yellowStyle := lipgloss.NewStyle().Foreground(ui.ColorYellow).Bold(true)
-		arrowStyle := lipgloss.NewStyle().Foreground(ui.ColorWhite).Bold(true)
-		infoLines = append(infoLines, "  "+greenStyle.Render("dev")+arrowStyle.Render(" → ")+yellowStyle.Render("staging"))
-		infoLines = append(infoLines, "")
-		infoLines = append(infoLines, "  Merge feature branches into")
-		infoLines = append(infoLines, "  staging for QA testing.")
-		infoLines = append(infoLines, "")
-		labelStyle := lipgloss.NewStyle().Foreground(ui.ColorWhite)
-		infoLines = append(infoLines, labelStyle.Render("  Base: ")+yellowStyle.Render("staging"))
-		infoLines = append(infoLines, labelStyle.Render("  Head: ")+greenStyle.Render("dev"))
-	} else {
-		yellowStyle := lipgloss.NewStyle().Foreground(ui.ColorYellow).Bold(true)
-		redStyle := lipgloss.NewStyle().Foreground(ui.ColorRed).Bold(true)
+	if m.menuIndex < len(types) {
+		t := types[m.menuIndex]
+		headStyle := lipgloss.NewStyle().Foreground(t.headColor).Bold(true)
+		baseStyle := lipgloss.NewStyle().Foreground(t.baseColor).Bold(true)
 		arrowStyle := lipgloss.NewStyle().Foreground(ui.ColorWhite).Bold(true)
-		infoLines = append(infoLines, "  "+yellowStyle.Render("staging")+arrowStyle.Render(" → ")+redStyle.Render(mainBranch))
+		infoLines = append(infoLines, "  "+headStyle.Render(t.head)+arrowStyle.Render(" → ")+baseStyle.Render(t.base))
 		infoLines = append(infoLines, "")
-		infoLines = append(infoLines, "  Release staging changes to")
-		infoLines = append(infoLines, "  production environment.")
+		if prTypes[m.menuIndex].Final {
+			infoLines = append(infoLines, "  Release "+t.head+" changes to")
+			infoLines = append(infoLines, "  production environment.")
+		} else {
+			infoLines = append(infoLines, "  Merge "+t.head+" changes into")
+			infoLines = append(infoLines, "  "+t.base+" for QA testing.")
+		}
 		infoLines = append(infoLines, "")
 		labelStyle := lipgloss.NewStyle().Foreground(ui.ColorWhite)
-		infoLines = append(infoLines, labelStyle.Render("  Base: ")+redStyle.Render(mainBranch))
-		infoLines = append(infoLines, labelStyle.Render("  Head: ")+yellowStyle.Render("staging"))
+		infoLines = append(infoLines, labelStyle.Render("  Base: ")+baseStyle.Render(t.base))
+		infoLines = append(infoLines, labelStyle.Render("  Head: ")+headStyle.Render(t.head))
 	}
 
 	infoTitleStyle := lipgloss.NewStyle().Bold(true).Foreground(ui.ColorWhite)
@@ -276,6 +285,14 @@ func (m Model) renderPrTypeSelect() string {
 	return ui.UnifiedPanel(menuContent, infoContent, 48, 48, ui.ColorCyan)
 }
 
+// prTypeDescription returns the one-line menu description for a promotion
+func prTypeDescription(pt models.PrType, mainBranch string) string {
+	if pt.Final {
+		return "Release to production"
+	}
+	return "Merge to " + pt.BaseBranch(mainBranch) + " for QA"
+}
+
 func (m Model) renderLoading() string {
 	message := m.loadingMessage
 	spinner := ui.Spinner(m.spinnerFrame)
@@ -330,7 +347,7 @@ func (m Model) renderCommitReviewWithHeight(availableHeight int) string {
 	if m.prType != nil {
 		labelStyle := lipgloss.NewStyle().Foreground(ui.ColorDarkGray)
 		arrowStyle := lipgloss.NewStyle().Foreground(ui.ColorWhite)
-		headBranch := m.prType.HeadBranch()
+		headBranch := m.prType.HeadBranch(mainBranch)
 		baseBranch := m.prType.BaseBranch(mainBranch)
 		headStyle := lipgloss.NewStyle().Foreground(ui.BranchColor(headBranch)).Bold(true)
 		baseStyle := lipgloss.NewStyle().Foreground(ui.BranchColor(baseBranch)).Bold(true)
@@ -381,6 +398,17 @@ func (m Model) renderCommitReviewWithHeight(availableHeight int) string {
 		leftLines = append(leftLines, "")
 	}
 
+	// Changelog toggle
+	if len(m.commits) > 0 {
+		labelStyle := lipgloss.NewStyle().Foreground(ui.ColorDarkGray)
+		state := lipgloss.NewStyle().Foreground(ui.ColorDarkGray).Render("off")
+		if m.prChangelog {
+			state = lipgloss.NewStyle().Foreground(ui.ColorGreen).Bold(true).Render("on")
+		}
+		leftLines = append(leftLines, labelStyle.Render("  Changelog: ")+state+labelStyle.Render(" (Tab)"))
+		leftLines = append(leftLines, "")
+	}
+
 	// Tickets section
 	ticketTitleStyle := lipgloss.NewStyle().Bold(true).Foreground(ui.ColorWhite)
 	leftLines = append(leftLines, ticketTitleStyle.Render(fmt.Sprintf(" Tickets (%d) ", len(m.tickets))))
@@ -486,7 +514,7 @@ func (m Model) renderTitleInput() string {
 
 	// Show branch flow
 	if m.prType != nil {
-		leftLines = append(leftLines, ui.BranchFlowDiagram(m.prType.HeadBranch(), m.prType.BaseBranch(mainBranch)))
+		leftLines = append(leftLines, ui.BranchFlowDiagram(m.prType.HeadBranch(mainBranch), m.prType.BaseBranch(mainBranch)))
 		leftLines = append(leftLines, "")
 	}
 
@@ -617,16 +645,18 @@ func (m Model) renderConfirmation() string {
 
 	// Show branch flow diagram
 	if m.prType != nil {
-		leftLines = append(leftLines, ui.BranchFlowDiagram(m.prType.HeadBranch(), m.prType.BaseBranch(mainBranch)))
+		leftLines = append(leftLines, ui.BranchFlowDiagram(m.prType.HeadBranch(mainBranch), m.prType.BaseBranch(mainBranch)))
 		leftLines = append(leftLines, "")
 	}
 
 	leftLines = append(leftLines, ui.SectionHeader("PR DETAILS", ui.ColorCyan))
 	leftLines = append(leftLines, "")
 
+	title, body, templateErr := m.renderPR()
+
 	labelStyle := lipgloss.NewStyle().Foreground(ui.ColorWhite)
 	titleStyle := lipgloss.NewStyle().Foreground(ui.ColorWhite).Bold(true)
-	leftLines = append(leftLines, fmt.Sprintf("  📝 %s %s", labelStyle.Render("Title:"), titleStyle.Render(m.prTitle)))
+	leftLines = append(leftLines, fmt.Sprintf("  📝 %s %s", labelStyle.Render("Title:"), titleStyle.Render(title)))
 
 	if m.repoInfo != nil {
 		repoStyle := lipgloss.NewStyle().Foreground(ui.ColorCyan)
@@ -639,17 +669,27 @@ func (m Model) renderConfirmation() string {
 	leftLines = append(leftLines, ui.SectionHeader("PR BODY PREVIEW", ui.ColorYellow))
 	leftLines = append(leftLines, "")
 
-	if len(m.tickets) == 0 {
-		dimStyle := lipgloss.NewStyle().Foreground(ui.ColorDarkGray)
+	dimStyle := lipgloss.NewStyle().Foreground(ui.ColorDarkGray)
+	switch {
+	case templateErr != nil:
+		errStyle := lipgloss.NewStyle().Foreground(ui.ColorRed)
+		leftLines = append(leftLines, errStyle.Render("  ✗ PR template: "+templateErr.Error()))
+	case body == "":
 		leftLines = append(leftLines, dimStyle.Render("  (empty)"))
-	} else {
-		leftLines = append(leftLines, "  # Tickets")
-		leftLines = append(leftLines, "")
-		for _, ticket := range m.tickets {
-			ticketStyle := lipgloss.NewStyle().Foreground(ui.ColorYellow)
-			urlStyle := lipgloss.NewStyle().Foreground(ui.ColorCyan)
-			linearURL := fmt.Sprintf("https://linear.app/%s/issue/%s", m.config.Tickets.LinearOrg, strings.ToLower(ticket))
-			leftLines = append(leftLines, fmt.Sprintf("  ### - Closes %s%s", ticketStyle.Render(fmt.Sprintf("[%s]", ticket)), urlStyle.Render(fmt.Sprintf("(%s)", linearURL))))
+	default:
+		// Live preview of the rendered body, trimmed to keep the panel on screen
+		const maxPreviewLines = 15
+		ticketStyle := lipgloss.NewStyle().Foreground(ui.ColorYellow)
+		bodyLines := strings.Split(body, "\n")
+		for i, line := range bodyLines {
+			if i >= maxPreviewLines {
+				leftLines = append(leftLines, dimStyle.Render(fmt.Sprintf("  ... and %d more lines", len(bodyLines)-maxPreviewLines)))
+				break
+			}
+			if strings.HasPrefix(line, "#") {
+				line = ticketStyle.Render(line)
+			}
+			leftLines = append(leftLines, "  "+line)
 		}
 	}
 
@@ -664,6 +704,7 @@ func (m Model) renderConfirmation() string {
 	if isUpdate {
 		warningStyle := lipgloss.NewStyle().Foreground(ui.ColorYellow).Bold(true)
 		leftLines = append(leftLines, warningStyle.Render("  ⚠ PR already exists - will update"))
+		leftLines = append(leftLines, dimStyle.Render("  Edits outside attpr's section of the body are kept"))
 		leftLines = append(leftLines, "")
 		leftLines = append(leftLines, "  Update this PR?")
 	} else {
@@ -685,7 +726,7 @@ func (m Model) renderConfirmation() string {
 
 	// Branch flow
 	if m.prType != nil {
-		headBranch := m.prType.HeadBranch()
+		headBranch := m.prType.HeadBranch(mainBranch)
 		baseBranch := m.prType.BaseBranch(mainBranch)
 		headStyle := lipgloss.NewStyle().Foreground(ui.BranchColor(headBranch)).Bold(true)
 		baseStyle := lipgloss.NewStyle().Foreground(ui.BranchColor(baseBranch)).Bold(true)
@@ -762,13 +803,14 @@ func (m Model) renderCreating() string {
 
 		labelStyle := lipgloss.NewStyle().Foreground(ui.ColorWhite)
 		repoStyle := lipgloss.NewStyle().Foreground(ui.ColorCyan)
-		headStyle := lipgloss.NewStyle().Foreground(ui.BranchColor(m.prType.HeadBranch())).Bold(true)
+		headStyle := lipgloss.NewStyle().Foreground(ui.BranchColor(m.prType.HeadBranch(mainBranch))).Bold(true)
 		baseStyle := lipgloss.NewStyle().Foreground(ui.BranchColor(m.prType.BaseBranch(mainBranch))).Bold(true)
 		titleStyle := lipgloss.NewStyle().Foreground(ui.ColorYellow)
 
 		lines = append(lines, labelStyle.Render("  Repo:   ")+repoStyle.Render(m.repoInfo.DisplayName))
-		lines = append(lines, labelStyle.Render("  Branch: ")+headStyle.Render(m.prType.HeadBranch())+labelStyle.Render(" -> ")+baseStyle.Render(m.prType.BaseBranch(mainBranch)))
-		lines = append(lines, labelStyle.Render("  Title:  ")+titleStyle.Render(m.prTitle))
+		lines = append(lines, labelStyle.Render("  Branch: ")+headStyle.Render(m.prType.HeadBranch(mainBranch))+labelStyle.Render(" -> ")+baseStyle.Render(m.prType.BaseBranch(mainBranch)))
+		title, _, _ := m.renderPR()
+		lines = append(lines, labelStyle.Render("  Title:  ")+titleStyle.Render(title))
 	}
 
 	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(ui.ColorCyan)
@@ -885,8 +927,9 @@ func (m Model) renderBatchRepoSelectWithHeight(availableHeight int) string {
 		}
 	}
 
-	// Fixed column width for stable layout
-	columnWidth := (m.contentWidth() - 6) / 2
+	// One column per repo group, fixed width for stable layout
+	columnCount := max(len(m.batchGroups), 1)
+	columnWidth := (m.contentWidth() - 2 - 2*columnCount) / columnCount
 
 	// Reserve space for commits panel (5 lines) + filter box (4 lines) + gaps (4)
 	commitsHeight := 5
@@ -895,131 +938,86 @@ func (m Model) renderBatchRepoSelectWithHeight(availableHeight int) string {
 		columnHeight = 5
 	}
 
-	// Filter width matches the two columns + gap
-	filterWidth := columnWidth*2 + 2
+	// Filter width matches the columns + gaps
+	filterWidth := columnWidth*columnCount + 2*(columnCount-1)
 
 	// Filter input at top
 	title := fmt.Sprintf("Select Repositories (%d/%d)", selectedCount, len(m.batchRepos))
 	filterBox := ui.FilterInput(m.batchFilter, title, ui.ColorWhite, filterWidth)
 
-	// Get filtered repos for each column
-	feFiltered := m.getFilteredBatchRepos(0)
-	beFiltered := m.getFilteredBatchRepos(1)
-
 	// Track highlighted repo index for commits panel
 	var highlightedRepoIdx int = -1
 
-	// Build Frontend column - track line index for highlighted item
-	var feLines []string
-	feHighlightedLine := -1
-	feLines = append(feLines, ui.SectionHeader(fmt.Sprintf("🖥️  FRONTEND (%d)", len(feFiltered)), ui.ColorCyan))
-	feLines = append(feLines, "")
-
-	if len(feFiltered) == 0 {
-		dimStyle := lipgloss.NewStyle().Foreground(ui.ColorDarkGray)
-		feLines = append(feLines, dimStyle.Render("  No repos found"))
-	} else {
-		var feCurrentParent *string
-		for i, repoIdx := range feFiltered {
-			repo := m.batchRepos[repoIdx]
-
-			// Show parent header when parent changes (only when not filtering)
-			if m.batchFilter == "" && !ptrEqual(repo.ParentRepo, feCurrentParent) {
-				if repo.ParentRepo != nil {
-					feLines = append(feLines, ui.ParentHeader(*repo.ParentRepo))
-				}
-				feCurrentParent = repo.ParentRepo
-			}
-
-			name := repo.ShortName()
-			selected := false
-			if repoIdx < len(m.batchSelected) {
-				selected = m.batchSelected[repoIdx]
-			}
-			highlighted := m.batchColumn == 0 && m.batchFEIndex == i
-			if highlighted {
-				feHighlightedLine = len(feLines)
-				highlightedRepoIdx = repoIdx
-			}
-
-			// Get commit count: -1 = loading, 0 = no commits, >0 = has commits
-			commitCount := -1 // Default to loading
-			if repoIdx < len(m.batchRepoCommits) && m.batchRepoCommits[repoIdx] != nil {
-				commitCount = len(*m.batchRepoCommits[repoIdx])
-			}
-
-			// Indent nested repos
-			indent := ""
-			if repo.ParentRepo != nil {
-				indent = "│ "
-			}
-			feLines = append(feLines, ui.RepoListItemWithCommits(name, selected, highlighted, ui.ColorCyan, indent, commitCount, m.spinnerFrame))
-		}
+	// Apply viewport scrolling to keep highlighted item visible
+	// Keep 2-line header, scroll the rest
+	headerLines := 2
+	visibleContentLines := columnHeight - headerLines
+	if visibleContentLines < 1 {
+		visibleContentLines = 1
 	}
 
-	// Build Backend column - track line index for highlighted item
-	var beLines []string
-	beHighlightedLine := -1
-	beLines = append(beLines, ui.SectionHeader(fmt.Sprintf("⚙️  BACKEND (%d)", len(beFiltered)), ui.ColorMagenta))
-	beLines = append(beLines, "")
+	var groupColumns []string
+	for col, group := range m.batchGroups {
+		color := ui.GroupColor(col)
+		filtered := m.getFilteredBatchRepos(col)
+		rowIndex := 0
+		if col < len(m.batchColumnIndex) {
+			rowIndex = m.batchColumnIndex[col]
+		}
 
-	if len(beFiltered) == 0 {
-		dimStyle := lipgloss.NewStyle().Foreground(ui.ColorDarkGray)
-		beLines = append(beLines, dimStyle.Render("  No repos found"))
-	} else {
-		var beCurrentParent *string
-		for i, repoIdx := range beFiltered {
-			repo := m.batchRepos[repoIdx]
+		// Build column - track line index for highlighted item
+		var colLines []string
+		highlightedLine := -1
+		colLines = append(colLines, ui.SectionHeader(fmt.Sprintf("%s %s (%d)", ui.GroupIcon(group), strings.ToUpper(group), len(filtered)), color))
+		colLines = append(colLines, "")
 
-			// Show parent header when parent changes (only when not filtering)
-			if m.batchFilter == "" && !ptrEqual(repo.ParentRepo, beCurrentParent) {
-				if repo.ParentRepo != nil {
-					beLines = append(beLines, ui.ParentHeader(*repo.ParentRepo))
+		if len(filtered) == 0 {
+			dimStyle := lipgloss.NewStyle().Foreground(ui.ColorDarkGray)
+			colLines = append(colLines, dimStyle.Render("  No repos found"))
+		} else {
+			var currentParent *string
+			for i, repoIdx := range filtered {
+				repo := m.batchRepos[repoIdx]
+
+				// Show parent header when parent changes (only when not filtering)
+				if m.batchFilter == "" && !ptrEqual(repo.ParentRepo, currentParent) {
+					if repo.ParentRepo != nil {
+						colLines = append(colLines, ui.ParentHeader(*repo.ParentRepo))
+					}
+					currentParent = repo.ParentRepo
 				}
-				beCurrentParent = repo.ParentRepo
-			}
 
-			name := repo.ShortName()
-			selected := false
-			if repoIdx < len(m.batchSelected) {
-				selected = m.batchSelected[repoIdx]
-			}
-			highlighted := m.batchColumn == 1 && m.batchBEIndex == i
-			if highlighted {
-				beHighlightedLine = len(beLines)
-				highlightedRepoIdx = repoIdx
-			}
+				name := repo.ShortName()
+				selected := false
+				if repoIdx < len(m.batchSelected) {
+					selected = m.batchSelected[repoIdx]
+				}
+				highlighted := m.batchColumn == col && rowIndex == i
+				if highlighted {
+					highlightedLine = len(colLines)
+					highlightedRepoIdx = repoIdx
+				}
 
-			// Get commit count: -1 = loading, 0 = no commits, >0 = has commits
-			commitCount := -1 // Default to loading
-			if repoIdx < len(m.batchRepoCommits) && m.batchRepoCommits[repoIdx] != nil {
-				commitCount = len(*m.batchRepoCommits[repoIdx])
-			}
+				// Get commit count: -1 = loading, 0 = no commits, >0 = has commits
+				commitCount := -1 // Default to loading
+				if repoIdx < len(m.batchRepoCommits) && m.batchRepoCommits[repoIdx] != nil {
+					commitCount = len(*m.batchRepoCommits[repoIdx])
+				}
 
-			// Indent nested repos
-			indent := ""
-			if repo.ParentRepo != nil {
-				indent = "│ "
+				// Indent nested repos
+				indent := ""
+				if repo.ParentRepo != nil {
+					indent = "│ "
+				}
+				colLines = append(colLines, ui.RepoListItemWithCommits(name, selected, highlighted, color, indent, commitCount, m.spinnerFrame))
 			}
-			beLines = append(beLines, ui.RepoListItemWithCommits(name, selected, highlighted, ui.ColorMagenta, indent, commitCount, m.spinnerFrame))
 		}
-	}
 
-	// Apply viewport scrolling to keep highlighted item visible
-	// Keep 2-line header, scroll the rest
-	headerLines := 2
-	visibleContentLines := columnHeight - headerLines
-	if visibleContentLines < 1 {
-		visibleContentLines = 1
+		content := applyViewportScroll(colLines, headerLines, highlightedLine, visibleContentLines)
+		groupColumns = append(groupColumns, ui.ColumnBox(content, "", color, m.batchColumn == col, columnWidth, columnHeight))
 	}
 
-	feContent := applyViewportScroll(feLines, headerLines, feHighlightedLine, visibleContentLines)
-	beContent := applyViewportScroll(beLines, headerLines, beHighlightedLine, visibleContentLines)
-
-	feColumn := ui.ColumnBox(feContent, "", ui.ColorCyan, m.batchColumn == 0, columnWidth, columnHeight)
-	beColumn := ui.ColumnBox(beContent, "", ui.ColorMagenta, m.batchColumn == 1, columnWidth, columnHeight)
-
-	columns := ui.TwoColumns(feColumn, beColumn, 2)
+	columns := ui.Columns(2, groupColumns...)
 
 	// Build commits preview panel for highlighted repo
 	commitsPanel := m.renderCommitsPreview(highlightedRepoIdx, filterWidth)
@@ -1190,7 +1188,7 @@ func (m Model) renderBatchConfirmationWithHeight(availableHeight int) string {
 
 	// Branch flow diagram
 	if m.prType != nil {
-		leftLines = append(leftLines, ui.BranchFlowDiagram(m.prType.HeadBranch(), m.prType.BaseBranch("main")))
+		leftLines = append(leftLines, ui.BranchFlowDiagram(m.prType.HeadBranch("main"), m.prType.BaseBranch("main")))
 		leftLines = append(leftLines, "")
 	}
 
@@ -1410,31 +1408,40 @@ func (m Model) renderBatchProcessing() string {
 	var lines []string
 
 	// Header with count - use selected count, not total repos
-	// len(batchResults) = completed, +1 if currently processing one
-	processedCount := len(m.batchResults)
-	if m.batchCurrentRepo != "" {
-		processedCount++
-	}
 	countStyle := lipgloss.NewStyle().Foreground(ui.ColorWhite)
-	header := fmt.Sprintf("Processing Repositories %s", countStyle.Render(fmt.Sprintf("(%d/%d)", processedCount, m.batchTotal)))
+	header := fmt.Sprintf("Processing Repositories %s", countStyle.Render(fmt.Sprintf("(%d/%d done)", len(m.batchResults), m.batchTotal)))
 	lines = append(lines, ui.SectionHeader(header, ui.ColorMagenta))
 	lines = append(lines, "")
 
-	// Current repo being processed
+	// One row per repo being processed, in list order
 	spinner := ui.Spinner(m.spinnerFrame)
 	spinnerStyle := lipgloss.NewStyle().Foreground(ui.ColorCyan)
 	repoStyle := lipgloss.NewStyle().Foreground(ui.ColorYellow).Bold(true)
 	stepStyle := lipgloss.NewStyle().Foreground(ui.ColorWhite)
+	dimStyle := lipgloss.NewStyle().Foreground(ui.ColorDarkGray)
 
-	if m.batchCurrentRepo != "" {
-		lines = append(lines, fmt.Sprintf("   %s Processing %s...",
+	inFlight := make([]int, 0, len(m.batchInFlight))
+	for i := range m.batchInFlight {
+		inFlight = append(inFlight, i)
+	}
+	sort.Ints(inFlight)
+	nameWidth := 0
+	for _, i := range inFlight {
+		nameWidth = max(nameWidth, lipgloss.Width(m.batchRepos[i].DisplayName))
+	}
+	for _, i := range inFlight {
+		step := m.batchInFlight[i]
+		if step == "" {
+			step = "Starting..."
+		}
+		lines = append(lines, fmt.Sprintf("   %s %s  %s",
 			spinnerStyle.Render(spinner),
-			repoStyle.Render(m.batchCurrentRepo),
+			repoStyle.Render(fmt.Sprintf("%-*s", nameWidth, m.batchRepos[i].DisplayName)),
+			stepStyle.Render(step),
 		))
-		// Show current step if available
-		if m.batchCurrentStep != "" {
-			lines = append(lines, fmt.Sprintf("      → %s", stepStyle.Render(m.batchCurrentStep)))
-		}
+	}
+	if len(m.batchQueue) > 0 {
+		lines = append(lines, dimStyle.Render(fmt.Sprintf("   %d more queued", len(m.batchQueue))))
 	}
 	lines = append(lines, "")
 
@@ -1644,8 +1651,12 @@ func (m Model) renderViewOpenPrsWithHeight(availableHeight int) string {
 		return strings.Join(lines, "\n")
 	}
 
-	// Fixed column dimensions for stable layout (same as batch select)
-	columnWidth := (m.contentWidth() - 6) / 2
+	// One column per promotion in the global flow
+	prTypes := m.config.ReleaseFlow().PrTypes()
+	columnCount := len(prTypes)
+
+	// Fixed column dimensions for stable layout (two columns match batch select)
+	columnWidth := (m.contentWidth() - 2 - 2*columnCount) / columnCount
 
 	// Column height calculation
 	columnHeight := availableHeight - 8
@@ -1653,8 +1664,8 @@ func (m Model) renderViewOpenPrsWithHeight(availableHeight int) string {
 		columnHeight = 5
 	}
 
-	// Title bar width matches the two columns + gap
-	titleWidth := columnWidth*2 + 2
+	// Title bar width matches the columns + gaps
+	titleWidth := columnWidth*columnCount + 2*(columnCount-1)
 
 	// Count selected
 	selectedCount := 0
@@ -1666,91 +1677,135 @@ func (m Model) renderViewOpenPrsWithHeight(availableHeight int) string {
 
 	// Title bar (similar to batch select filter box)
 	title := fmt.Sprintf("Open Release PRs (%d selected)", selectedCount)
-	titleBox := ui.FilterInput("", title, ui.ColorYellow, titleWidth)
-
-	// Build Dev → Staging column
-	var devLines []string
-	devHighlightedLine := -1
-	devLines = append(devLines, ui.SectionHeader("🟢 DEV → STAGING", ui.ColorGreen))
-	devLines = append(devLines, "")
-
-	devCount := 0
-	for i, pr := range m.mergePRs {
-		if pr.PrType == models.DevToStaging {
-			selected := false
-			if i < len(m.mergeSelected) {
-				selected = m.mergeSelected[i]
-			}
-			highlighted := m.mergeColumn == 0 && m.mergeDevIndex == devCount
-			if highlighted {
-				devHighlightedLine = len(devLines)
-			}
-			devLines = append(devLines, ui.PRListItem(pr.Repo.ShortName(), pr.PrNumber, selected, highlighted, ui.ColorGreen))
-			devCount++
-		}
+	if m.mergeAuto {
+		title += " · auto-merge"
 	}
-	if devCount == 0 {
-		dimStyle := lipgloss.NewStyle().Foreground(ui.ColorDarkGray)
-		devLines = append(devLines, dimStyle.Render("  No open PRs"))
+	if m.mergeWait {
+		title += " · merge when green"
+	}
+	if m.mergeOverride {
+		title += " · ⚠ merge checks overridden"
 	}
+	titleBox := ui.FilterInput("", title, ui.ColorYellow, titleWidth)
 
-	// Build Staging → Main column
-	var mainLines []string
-	mainHighlightedLine := -1
-	mainLines = append(mainLines, ui.SectionHeader("🔴 STAGING → MAIN", ui.ColorRed))
-	mainLines = append(mainLines, "")
+	// Apply viewport scrolling to keep highlighted item visible
+	headerLines := 2
+	visibleContentLines := columnHeight - headerLines
+	if visibleContentLines < 1 {
+		visibleContentLines = 1
+	}
 
-	mainCount := 0
-	for i, pr := range m.mergePRs {
-		if pr.PrType == models.StagingToMain {
+	var columns []string
+	for col, pt := range prTypes {
+		color := ui.StageColor(col, columnCount)
+		rowIndex := 0
+		if col < len(m.mergeColumnIndex) {
+			rowIndex = m.mergeColumnIndex[col]
+		}
+
+		var colLines []string
+		highlightedLine := -1
+		header := fmt.Sprintf("%s %s → %s", ui.StageDot(col, columnCount), strings.ToUpper(pt.Head), strings.ToUpper(pt.Base))
+		colLines = append(colLines, ui.SectionHeader(header, color))
+		colLines = append(colLines, "")
+
+		count := 0
+		currentGroup := ""
+		for _, i := range m.getFilteredMergePRs(col) {
+			pr := m.mergePRs[i]
+			// Section per repo group
+			if pr.Repo.Group != currentGroup {
+				currentGroup = pr.Repo.Group
+				colLines = append(colLines, ui.GroupHeader(currentGroup, m.groupColor(currentGroup)))
+			}
 			selected := false
 			if i < len(m.mergeSelected) {
 				selected = m.mergeSelected[i]
 			}
-			highlighted := m.mergeColumn == 1 && m.mergeMainIndex == mainCount
+			highlighted := m.mergeColumn == col && rowIndex == count
 			if highlighted {
-				mainHighlightedLine = len(mainLines)
+				highlightedLine = len(colLines)
+			}
+			item := ui.PRListItem(pr.Repo.ShortName(), pr.PrNumber, selected, highlighted, color)
+			if badges := ui.MergeBadges(pr.Mergeable, pr.ReviewDecision, pr.ChecksState, pr.AutoMerge); badges != "" {
+				item += " " + badges
 			}
-			mainLines = append(mainLines, ui.PRListItem(pr.Repo.ShortName(), pr.PrNumber, selected, highlighted, ui.ColorRed))
-			mainCount++
+			colLines = append(colLines, item)
+			count++
+		}
+		if count == 0 {
+			dimStyle := lipgloss.NewStyle().Foreground(ui.ColorDarkGray)
+			colLines = append(colLines, dimStyle.Render("  No open PRs"))
 		}
-	}
-	if mainCount == 0 {
-		dimStyle := lipgloss.NewStyle().Foreground(ui.ColorDarkGray)
-		mainLines = append(mainLines, dimStyle.Render("  No open PRs"))
-	}
 
-	// Apply viewport scrolling to keep highlighted item visible
-	headerLines := 2
-	visibleContentLines := columnHeight - headerLines
-	if visibleContentLines < 1 {
-		visibleContentLines = 1
+		// Use same height for all columns
+		content := applyViewportScroll(colLines, headerLines, highlightedLine, visibleContentLines)
+		columns = append(columns, ui.ColumnBox(content, "", color, m.mergeColumn == col, columnWidth, columnHeight))
 	}
 
-	devContent := applyViewportScroll(devLines, headerLines, devHighlightedLine, visibleContentLines)
-	mainContent := applyViewportScroll(mainLines, headerLines, mainHighlightedLine, visibleContentLines)
-
-	// Use same height for both columns
-	devColumn := ui.ColumnBox(devContent, "", ui.ColorGreen, m.mergeColumn == 0, columnWidth, columnHeight)
-	mainColumn := ui.ColumnBox(mainContent, "", ui.ColorRed, m.mergeColumn == 1, columnWidth, columnHeight)
-
-	return titleBox + "\n" + ui.TwoColumns(devColumn, mainColumn, 2)
+	return titleBox + "\n" + ui.Columns(2, columns...)
 }
 
 func (m Model) renderMergeConfirmation() string {
 	var lines []string
 
-	lines = append(lines, ui.SectionHeader("Confirm Merge", ui.ColorMagenta))
+	if m.mergeWait {
+		lines = append(lines, ui.SectionHeader("Confirm Merge When Green", ui.ColorMagenta))
+		lines = append(lines, "")
+		lines = append(lines, fmt.Sprintf("   PRs to merge: %d", m.mergeSelectedCount()))
+		lines = append(lines, lipgloss.NewStyle().Foreground(ui.ColorDarkGray).Render(fmt.Sprintf(
+			"   Each PR merges as soon as its workflow runs pass (gives up after %s)", m.config.MergeWait.TimeoutOrDefault())))
+	} else if m.mergeAuto {
+		lines = append(lines, ui.SectionHeader("Confirm Auto-Merge", ui.ColorMagenta))
+		lines = append(lines, "")
+		lines = append(lines, fmt.Sprintf("   PRs to auto-merge: %d", m.mergeSelectedCount()))
+		lines = append(lines, lipgloss.NewStyle().Foreground(ui.ColorDarkGray).Render("   GitHub merges each PR once its required checks and reviews pass"))
+	} else {
+		lines = append(lines, ui.SectionHeader("Confirm Merge", ui.ColorMagenta))
+		lines = append(lines, "")
+		lines = append(lines, fmt.Sprintf("   PRs to merge: %d", m.mergeSelectedCount()))
+	}
 	lines = append(lines, "")
 
-	selected := 0
-	for _, s := range m.mergeSelected {
-		if s {
-			selected++
+	// List selected PRs in merge order, flagging any that are only allowed by the override
+	dimStyle := lipgloss.NewStyle().Foreground(ui.ColorDarkGray)
+	warnStyle := lipgloss.NewStyle().Foreground(ui.ColorRed)
+	strategyStyle := lipgloss.NewStyle().Foreground(ui.ColorCyan)
+	queue, deps, orderErr := m.selectedMergeOrder()
+	if orderErr != nil {
+		lines = append(lines, warnStyle.Render("   ✗ "+orderErr.Error()))
+		lines = append(lines, "")
+	}
+	for _, i := range queue {
+		pr := m.mergePRs[i]
+		line := fmt.Sprintf("   %s %s %s %s", pr.Repo.DisplayName, dimStyle.Render(fmt.Sprintf("#%d", pr.PrNumber)),
+			strategyStyle.Render(pr.MergeMethod().Describe()), ui.MergeBadges(pr.Mergeable, pr.ReviewDecision, pr.ChecksState, pr.AutoMerge))
+		if blockers := m.mergeBlockers(pr); len(blockers) > 0 {
+			line += " " + warnStyle.Render("("+strings.Join(blockers, ", ")+")")
+		}
+		if len(deps[i]) > 0 {
+			var after []string
+			for _, d := range deps[i] {
+				name := m.mergePRs[d].Repo.DisplayName
+				if workflow := m.mergePRs[d].Repo.Settings.DeployWorkflow; workflow != "" && !m.mergeAuto {
+					name += " + " + workflow
+				}
+				after = append(after, name)
+			}
+			line += " " + dimStyle.Render("after "+strings.Join(after, ", "))
 		}
+		lines = append(lines, line)
+	}
+	if len(deps) > 0 && m.mergeAuto {
+		lines = append(lines, dimStyle.Render("   Auto-merge is enabled in merge_after order, but GitHub decides when each PR merges"))
 	}
+	lines = append(lines, "")
 
-	lines = append(lines, fmt.Sprintf("   PRs to merge: %d", selected))
+	if m.mergeOverride {
+		lines = append(lines, warnStyle.Bold(true).Render("   ⚠ Override ON: blocked PRs will be merged anyway (f to turn off)"))
+	} else {
+		lines = append(lines, dimStyle.Render("   Override off: PRs with conflicts, failing checks or missing reviews can't be selected (f to allow)"))
+	}
 	lines = append(lines, "")
 
 	if m.dryRun {
@@ -1765,6 +1820,10 @@ func (m Model) renderMergeConfirmation() string {
 }
 
 func (m Model) renderMerging() string {
+	if m.mergeWait {
+		return m.renderMergeWait()
+	}
+
 	var lines []string
 
 	lines = append(lines, ui.SectionHeader("Merging PRs", ui.ColorMagenta))
@@ -1774,25 +1833,85 @@ func (m Model) renderMerging() string {
 	spinnerStyle := lipgloss.NewStyle().Foreground(ui.ColorYellow)
 	statusStyle := lipgloss.NewStyle().Foreground(ui.ColorMagenta)
 
+	mergingMessage := fmt.Sprintf("Merging PRs (%d/%d)...", m.mergeCurrent+1, len(m.mergeQueue))
+	if m.mergeAuto {
+		mergingMessage = "Enabling auto-merge..."
+	}
+	if m.mergeDeployWaiting != "" && m.mergeCurrent < len(m.mergeQueue) {
+		next := m.mergePRs[m.mergeQueue[m.mergeCurrent]]
+		mergingMessage = fmt.Sprintf("Waiting to merge %s: %s", next.Repo.DisplayName, m.mergeDeployWaiting)
+	}
 	lines = append(lines, fmt.Sprintf("   %s %s",
 		spinnerStyle.Render(spinner),
-		statusStyle.Render("Merging PRs..."),
+		statusStyle.Render(mergingMessage),
 	))
 
 	return strings.Join(lines, "\n")
 }
 
+// renderMergeWait shows each PR's progress in wait-for-checks merge mode
+func (m Model) renderMergeWait() string {
+	var lines []string
+
+	lines = append(lines, ui.SectionHeader("Merging When Green", ui.ColorMagenta))
+	lines = append(lines, "")
+
+	dimStyle := lipgloss.NewStyle().Foreground(ui.ColorDarkGray)
+	elapsed := time.Since(m.mergeWaitStarted).Truncate(time.Second)
+	lines = append(lines, dimStyle.Render(fmt.Sprintf("   Waiting %s of %s · checking every %s",
+		elapsed, m.config.MergeWait.TimeoutOrDefault(), m.config.MergeWait.PollIntervalOrDefault())))
+	lines = append(lines, "")
+
+	repoStyle := lipgloss.NewStyle().Foreground(ui.ColorWhite).Bold(true)
+	for _, e := range m.mergeWaitEntries {
+		pr := m.mergePRs[e.prIndex]
+
+		var icon string
+		var color lipgloss.Color
+		switch e.state {
+		case mergeWaitWaiting:
+			icon, color = "◌", ui.ColorDarkGray
+		case mergeWaitChecksRunning:
+			icon, color = ui.Spinner(m.spinnerFrame), ui.ColorYellow
+		case mergeWaitMerging:
+			icon, color = ui.Spinner(m.spinnerFrame), ui.ColorMagenta
+		case mergeWaitMerged:
+			icon, color = "✓", ui.ColorGreen
+		default:
+			icon, color = "✗", ui.ColorRed
+		}
+		stateStyle := lipgloss.NewStyle().Foreground(color)
+
+		line := fmt.Sprintf("   %s %s %s %s",
+			stateStyle.Render(icon),
+			repoStyle.Render(pr.Repo.DisplayName),
+			dimStyle.Render(fmt.Sprintf("#%d", pr.PrNumber)),
+			stateStyle.Render(e.state.String()),
+		)
+		if e.detail != "" {
+			line += dimStyle.Render(" · " + e.detail)
+		}
+		lines = append(lines, line)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
 func (m Model) renderMergeSummaryWithHeight(availableHeight int) string {
 	var lines []string
 
-	// Count successes and failures
+	// Count successes, auto-merges and failures
 	successCount := 0
+	autoCount := 0
 	failCount := 0
 	for _, result := range m.mergeResults {
-		if result.Success {
-			successCount++
-		} else {
+		switch {
+		case !result.Success:
 			failCount++
+		case result.AutoMerge:
+			autoCount++
+		default:
+			successCount++
 		}
 	}
 
@@ -1807,35 +1926,47 @@ func (m Model) renderMergeSummaryWithHeight(availableHeight int) string {
 
 	// Summary counts
 	successStyle := lipgloss.NewStyle().Foreground(ui.ColorGreen)
+	autoStyle := lipgloss.NewStyle().Foreground(ui.ColorCyan)
 	failStyle := lipgloss.NewStyle().Foreground(ui.ColorRed)
-	lines = append(lines, fmt.Sprintf("   %s %d succeeded  %s %d failed",
-		successStyle.Render("✓"),
-		successCount,
-		failStyle.Render("✗"),
-		failCount,
-	))
+	summary := fmt.Sprintf("   %s %d succeeded", successStyle.Render("✓"), successCount)
+	if autoCount > 0 {
+		summary += fmt.Sprintf("  %s %d auto-merge enabled", autoStyle.Render("⏲"), autoCount)
+	}
+	summary += fmt.Sprintf("  %s %d failed", failStyle.Render("✗"), failCount)
+	lines = append(lines, summary)
 	lines = append(lines, "")
 
 	// Individual results
 	for _, result := range m.mergeResults {
 		var icon string
 		var iconStyle lipgloss.Style
-		if result.Success {
-			icon = "✓"
-			iconStyle = lipgloss.NewStyle().Foreground(ui.ColorGreen)
-		} else {
+		switch {
+		case !result.Success:
 			icon = "✗"
 			iconStyle = lipgloss.NewStyle().Foreground(ui.ColorRed)
+		case result.AutoMerge:
+			icon = "⏲"
+			iconStyle = autoStyle
+		default:
+			icon = "✓"
+			iconStyle = lipgloss.NewStyle().Foreground(ui.ColorGreen)
 		}
 
 		repoStyle := lipgloss.NewStyle().Foreground(ui.ColorWhite).Bold(true)
 		dimStyle := lipgloss.NewStyle().Foreground(ui.ColorDarkGray)
 
-		lines = append(lines, fmt.Sprintf("   %s %s %s",
+		line := fmt.Sprintf("   %s %s %s",
 			iconStyle.Render(icon),
 			repoStyle.Render(result.RepoName),
 			dimStyle.Render(fmt.Sprintf("#%d", result.PrNumber)),
-		))
+		)
+		if result.AutoMerge {
+			line += " " + autoStyle.Render("auto-merge enabled")
+		}
+		if result.Error != nil {
+			line += dimStyle.Render(" · " + *result.Error)
+		}
+		lines = append(lines, line)
 	}
 
 	content := strings.Join(lines, "\n")
@@ -2052,10 +2183,7 @@ func (m Model) renderActionsRunList(filtered []int, width int) []string {
 				lines = append(lines, "") // gap between groups
 			}
 			currentRepo = entry.Repo.DisplayName
-			repoColor := ui.ColorMagenta
-			if entry.Repo.IsFrontend() {
-				repoColor = ui.ColorCyan
-			}
+			repoColor := m.groupColor(entry.Repo.Group)
 			repoStyle := lipgloss.NewStyle().Foreground(repoColor).Bold(true)
 			lines = append(lines, repoStyle.Render(currentRepo))
 		}
@@ -2172,10 +2300,7 @@ func (m Model) renderActionsPinnedPanels(width, maxHeight int, active bool) stri
 
 	var blocks []string
 	for i, panel := range m.actionsPinned {
-		borderColor := ui.ColorMagenta
-		if panel.Repo.IsFrontend() {
-			borderColor = ui.ColorCyan
-		}
+		borderColor := m.groupColor(panel.Repo.Group)
 		highlighted := active && i == m.actionsPinnedIndex
 		blocks = append(blocks, m.renderPinnedPanel(panel, borderColor, width, highlighted))
 	}
@@ -2279,6 +2404,15 @@ func relativeTime(t time.Time) string {
 	}
 }
 
+// numKeysHint labels the number keys that select one of n items (see numKeyIndex)
+func numKeysHint(n int) string {
+	n = min(n, 9)
+	if n <= 1 {
+		return "1"
+	}
+	return fmt.Sprintf("1-%d", n)
+}
+
 func (m Model) renderStatusBar() string {
 	var hints []string
 
@@ -2299,7 +2433,7 @@ func (m Model) renderStatusBar() string {
 		hints = append(hints, ui.KeyBinding("q", "Quit", ui.ColorRed))
 	case ScreenPrTypeSelect:
 		hints = []string{
-			ui.KeyBinding("1-2", "Select", ui.ColorYellow),
+			ui.KeyBinding(numKeysHint(len(m.prTypeOptions())), "Select", ui.ColorYellow),
 			ui.KeyBinding("↑↓", "Navigate", ui.ColorWhite),
 			ui.KeyBinding("Enter", "Select", ui.ColorGreen),
 			ui.KeyBinding("Esc", "Back", ui.ColorYellow),
@@ -2308,6 +2442,7 @@ func (m Model) renderStatusBar() string {
 		if len(m.commits) > 0 {
 			hints = []string{
 				ui.KeyBinding("Type", "Edit title", ui.ColorYellow),
+				ui.KeyBinding("Tab", "Changelog", ui.ColorCyan),
 				ui.KeyBinding("Enter", "Create PR", ui.ColorGreen),
 				ui.KeyBinding("Esc", "Back", ui.ColorYellow),
 			}
@@ -2321,13 +2456,22 @@ func (m Model) renderStatusBar() string {
 			ui.KeyBinding("Enter", "Submit", ui.ColorGreen),
 			ui.KeyBinding("Esc", "Back", ui.ColorYellow),
 		}
-	case ScreenConfirmation, ScreenBatchConfirmation, ScreenMergeConfirmation:
+	case ScreenConfirmation, ScreenBatchConfirmation, ScreenMergeConfirmation, ScreenPullResetConfirmation:
 		hints = []string{
 			ui.KeyBinding("←→", "Select", ui.ColorWhite),
 			ui.KeyBinding("y/n", "Quick", ui.ColorGreen),
 			ui.KeyBinding("Enter", "Confirm", ui.ColorGreen),
 			ui.KeyBinding("Esc", "Back", ui.ColorYellow),
 		}
+		if m.screen == ScreenMergeConfirmation {
+			hints = append(hints, ui.KeyBinding("f", "Override", ui.ColorRed))
+		}
+	case ScreenMerging:
+		if m.mergeWait || m.mergeDeployWaiting != "" {
+			hints = []string{
+				ui.KeyBinding("Esc", "Stop waiting", ui.ColorYellow),
+			}
+		}
 	case ScreenComplete:
 		hints = []string{
 			ui.KeyBinding("o", "Open URL", ui.ColorBlue),
@@ -2355,6 +2499,9 @@ func (m Model) renderStatusBar() string {
 				ui.KeyBinding("←→", "Column", ui.ColorWhite),
 				ui.KeyBinding("Space", "Toggle", ui.ColorGreen),
 				ui.KeyBinding("Tab", "Continue", ui.ColorGreen),
+				ui.KeyBinding("u", "Auto-merge", ui.ColorCyan),
+				ui.KeyBinding("w", "When green", ui.ColorCyan),
+				ui.KeyBinding("f", "Override", ui.ColorRed),
 				ui.KeyBinding("r", "Refresh", ui.ColorBlue),
 				ui.KeyBinding("Esc", "Back", ui.ColorYellow),
 			}
@@ -2398,9 +2545,11 @@ func (m Model) renderStatusBar() string {
 		}
 	case ScreenPullBranchSelect:
 		hints = []string{
-			ui.KeyBinding("1-3", "Select", ui.ColorYellow),
+			ui.KeyBinding(numKeysHint(len(m.config.ReleaseFlow().Stages)), "Select", ui.ColorYellow),
 			ui.KeyBinding("↑↓", "Navigate", ui.ColorWhite),
 			ui.KeyBinding("Enter", "Pull", ui.ColorGreen),
+			ui.KeyBinding("s", "Auto-stash", ui.ColorCyan),
+			ui.KeyBinding("b", "Mode", ui.ColorCyan),
 			ui.KeyBinding("Esc", "Back", ui.ColorYellow),
 		}
 	case ScreenPullProgress:
@@ -2408,8 +2557,11 @@ func (m Model) renderStatusBar() string {
 	case ScreenPullSummary:
 		hints = []string{
 			ui.KeyBinding("Enter", "Done", ui.ColorGreen),
-			ui.KeyBinding("q", "Quit", ui.ColorRed),
 		}
+		if m.pullResettableCount() > 0 {
+			hints = append(hints, ui.KeyBinding("r", "Reset to origin", ui.ColorRed))
+		}
+		hints = append(hints, ui.KeyBinding("q", "Quit", ui.ColorRed))
 	case ScreenActionsOverview:
 		if m.actionsFilterActive {
 			hints = []string{
@@ -2511,15 +2663,29 @@ func (m Model) renderPullBranchSelect() string {
 	var menuLines []string
 	menuLines = append(menuLines, "")
 
-	branches := []struct {
+	stages := m.config.ReleaseFlow().Stages
+
+	type branchOption struct {
 		num   string
 		name  string
 		desc  string
 		color lipgloss.Color
-	}{
-		{"1.", "dev", "Pull latest development changes", ui.ColorGreen},
-		{"2.", "staging", "Pull staging/QA changes", ui.ColorYellow},
-		{"3.", "main/master", "Pull production code", ui.ColorRed},
+	}
+	var branches []branchOption
+	for i, stage := range stages {
+		opt := branchOption{num: fmt.Sprintf("%d.", i+1), name: stage, color: ui.StageColor(i, len(stages))}
+		switch {
+		case i == 0:
+			opt.desc = "Pull latest development changes"
+		case i == len(stages)-1:
+			opt.desc = "Pull production code"
+		default:
+			opt.desc = "Pull " + stage + "/QA changes"
+		}
+		if stage == models.MainStage {
+			opt.name = "main/master"
+		}
+		branches = append(branches, opt)
 	}
 
 	for i, b := range branches {
@@ -2559,34 +2725,47 @@ func (m Model) renderPullBranchSelect() string {
 	menuContent := menuTitleStyle.Render(" Select Branch to Pull ") + "\n" + strings.Join(menuLines, "\n")
 
 	// Build right column (info panel)
-	branchInfo := []struct {
+	type branchDetails struct {
 		title string
 		color lipgloss.Color
 		lines []string
-	}{
-		{"dev", ui.ColorGreen, []string{
-			"Pull the latest development",
-			"branch across all repos.",
-			"",
-			"Use this to sync your local",
-			"dev environment with the team.",
-		}},
-		{"staging", ui.ColorYellow, []string{
-			"Pull the staging branch",
-			"across all repos.",
-			"",
-			"Use this to test QA builds",
-			"locally.",
-		}},
-		{"main / master", ui.ColorRed, []string{
-			"Pull the production branch",
-			"across all repos.",
-			"",
-			"Uses each repo's default",
-			"branch (main or master).",
-		}},
+	}
+	var branchInfo []branchDetails
+	for i, b := range branches {
+		info := branchDetails{title: b.name, color: b.color}
+		switch {
+		case i == 0:
+			info.lines = []string{
+				"Pull the latest development",
+				"branch across all repos.",
+				"",
+				"Use this to sync your local",
+				b.name + " environment with the team.",
+			}
+		case i == len(branches)-1:
+			info.lines = []string{
+				"Pull the production branch",
+				"across all repos.",
+			}
+			if stages[i] == models.MainStage {
+				info.title = "main / master"
+				info.lines = append(info.lines, "", "Uses each repo's default", "branch (main or master).")
+			}
+		default:
+			info.lines = []string{
+				"Pull the " + b.name + " branch",
+				"across all repos.",
+				"",
+				"Use this to test QA builds",
+				"locally.",
+			}
+		}
+		branchInfo = append(branchInfo, info)
 	}
 
+	if m.menuIndex >= len(branchInfo) {
+		return ui.UnifiedPanel(menuContent, "", 48, 48, ui.ColorCyan)
+	}
 	info := branchInfo[m.menuIndex]
 	titleStyle := lipgloss.NewStyle().Foreground(info.color).Bold(true)
 	var infoLines []string
@@ -2597,6 +2776,33 @@ func (m Model) renderPullBranchSelect() string {
 		infoLines = append(infoLines, "  "+line)
 	}
 
+	// Dirty repo handling
+	infoLines = append(infoLines, "")
+	if m.pullAutoStash {
+		onStyle := lipgloss.NewStyle().Foreground(ui.ColorGreen).Bold(true)
+		infoLines = append(infoLines, "  "+onStyle.Render("Auto-stash: on"))
+		infoLines = append(infoLines, "  Local changes are stashed and")
+		infoLines = append(infoLines, "  re-applied after pulling.")
+	} else {
+		offStyle := lipgloss.NewStyle().Foreground(ui.ColorDarkGray)
+		infoLines = append(infoLines, "  "+offStyle.Render("Auto-stash: off"))
+		infoLines = append(infoLines, "  Repos with local changes")
+		infoLines = append(infoLines, "  are skipped.")
+	}
+
+	// Whether the pulled branch stays checked out
+	modeStyle := lipgloss.NewStyle().Foreground(ui.ColorCyan).Bold(true)
+	infoLines = append(infoLines, "")
+	infoLines = append(infoLines, "  "+modeStyle.Render("Mode: "+m.pullMode))
+	switch m.pullMode {
+	case config.PullModeUpdate:
+		infoLines = append(infoLines, "  Fast-forwards the branch", "  without switching to it.")
+	case config.PullModeRestore:
+		infoLines = append(infoLines, "  Switches back to your branch", "  after pulling.")
+	default:
+		infoLines = append(infoLines, "  Leaves the branch checked out.")
+	}
+
 	infoTitleStyle := lipgloss.NewStyle().Bold(true).Foreground(ui.ColorWhite)
 	infoContent := infoTitleStyle.Render(" Branch Info ") + "\n" + strings.Join(infoLines, "\n")
 
@@ -2610,9 +2816,9 @@ func (m Model) renderPullProgress() string {
 	lines = append(lines, "")
 
 	// Header
-	branchStyle := lipgloss.NewStyle().Foreground(ui.BranchColor(m.pullBranch)).Bold(true)
+	branchStyle := lipgloss.NewStyle().Foreground(ui.StageColor(m.pullStage, len(m.config.ReleaseFlow().Stages))).Bold(true)
 	headerStyle := lipgloss.NewStyle().Foreground(ui.ColorWhite)
-	lines = append(lines, headerStyle.Render("  Pulling ")+branchStyle.Render(m.pullBranch)+headerStyle.Render(" across all repos..."))
+	lines = append(lines, headerStyle.Render("  Pulling ")+branchStyle.Render(m.pullBranch())+headerStyle.Render(" across all repos..."))
 	lines = append(lines, "")
 
 	// Show progress for each repo
@@ -2620,40 +2826,72 @@ func (m Model) renderPullProgress() string {
 	warnStyle := lipgloss.NewStyle().Foreground(ui.ColorYellow)
 	spinnerStyle := lipgloss.NewStyle().Foreground(ui.ColorCyan)
 	dimStyle := lipgloss.NewStyle().Foreground(ui.ColorDarkGray)
-	repoStyle := lipgloss.NewStyle().Foreground(ui.ColorCyan)
 
+	// Keep the earliest unfinished repo in view
+	firstPending := len(m.pullRepos)
+	for i := range m.pullRepos {
+		if _, done := m.pullResults[i]; !done {
+			firstPending = i
+			break
+		}
+	}
 	maxVisible := 15
 	startIdx := 0
-	if m.pullCurrentIdx > maxVisible-3 {
-		startIdx = m.pullCurrentIdx - (maxVisible - 3)
+	if firstPending > maxVisible-3 {
+		startIdx = firstPending - (maxVisible - 3)
 	}
 
+	currentGroup := ""
 	for i := startIdx; i < len(m.pullRepos) && i < startIdx+maxVisible; i++ {
 		repo := m.pullRepos[i]
-		name := repo.DisplayName
+
+		// Section header per repo group
+		if repo.Group != currentGroup {
+			if currentGroup != "" {
+				lines = append(lines, "")
+			}
+			currentGroup = repo.Group
+			lines = append(lines, ui.GroupHeader(repo.Group, m.groupColor(repo.Group)))
+		}
+		repoStyle := lipgloss.NewStyle().Foreground(m.groupColor(repo.Group))
+
+		name := strings.TrimPrefix(repo.DisplayName, repo.Group+"/")
 		if len(name) > 30 {
 			name = name[:27] + "..."
 		}
 
-		if i < len(m.pullResults) {
+		if result, done := m.pullResults[i]; done {
 			// Completed
-			result := m.pullResults[i]
 			var status string
 			switch result.Status {
 			case models.PullUpdated:
 				status = checkStyle.Render("✓") + " " + repoStyle.Render(name) + dimStyle.Render(fmt.Sprintf(" updated (%d commits)", result.CommitCount))
 			case models.PullUpToDate:
 				status = checkStyle.Render("✓") + " " + repoStyle.Render(name) + dimStyle.Render(" already up to date")
+			case models.PullCreated:
+				status = checkStyle.Render("✓") + " " + repoStyle.Render(name) + dimStyle.Render(" created from origin")
 			case models.PullSkippedNoBranch:
-				status = warnStyle.Render("⚠") + " " + repoStyle.Render(name) + dimStyle.Render(fmt.Sprintf(" skipped (no %s branch)", m.pullBranch))
+				status = warnStyle.Render("⚠") + " " + repoStyle.Render(name) + dimStyle.Render(fmt.Sprintf(" skipped (no %s branch)", m.pullBranch()))
 			case models.PullSkippedDirty:
 				status = warnStyle.Render("⚠") + " " + repoStyle.Render(name) + dimStyle.Render(" skipped (uncommitted changes)")
+			case models.PullStashRestored:
+				status = checkStyle.Render("✓") + " " + repoStyle.Render(name) + dimStyle.Render(fmt.Sprintf(" updated (%d commits), local changes restored", result.CommitCount))
+			case models.PullStashConflict:
+				status = warnStyle.Render("⚠") + " " + repoStyle.Render(name) + dimStyle.Render(" updated, local changes left in "+result.StashRef)
+			case models.PullAhead:
+				status = warnStyle.Render("⚠") + " " + repoStyle.Render(name) + dimStyle.Render(fmt.Sprintf(" ahead of origin by %d", result.Ahead))
+			case models.PullBehind:
+				errStyle := lipgloss.NewStyle().Foreground(ui.ColorRed)
+				status = errStyle.Render("✗") + " " + repoStyle.Render(name) + dimStyle.Render(fmt.Sprintf(" behind origin by %d, couldn't fast-forward", result.Behind))
+			case models.PullDiverged:
+				errStyle := lipgloss.NewStyle().Foreground(ui.ColorRed)
+				status = errStyle.Render("✗") + " " + repoStyle.Render(name) + dimStyle.Render(fmt.Sprintf(" diverged (%d local, %d on origin)", result.Ahead, result.Behind))
 			case models.PullFailed:
 				errStyle := lipgloss.NewStyle().Foreground(ui.ColorRed)
 				status = errStyle.Render("✗") + " " + repoStyle.Render(name) + dimStyle.Render(" failed")
 			}
 			lines = append(lines, "  "+status)
-		} else if i == m.pullCurrentIdx {
+		} else if m.pullInFlight[i] {
 			// Currently processing
 			spinner := ui.Spinner(m.spinnerFrame)
 			lines = append(lines, "  "+spinnerStyle.Render(spinner)+" "+repoStyle.Render(name)+dimStyle.Render(" pulling..."))
@@ -2671,7 +2909,7 @@ func (m Model) renderPullProgress() string {
 	lines = append(lines, "")
 
 	// Progress bar
-	progress := float64(m.pullCurrentIdx) / float64(len(m.pullRepos))
+	progress := float64(len(m.pullResults)) / float64(len(m.pullRepos))
 	barWidth := 40
 	filled := int(progress * float64(barWidth))
 	if filled > barWidth {
@@ -2681,7 +2919,7 @@ func (m Model) renderPullProgress() string {
 	progressStyle := lipgloss.NewStyle().Foreground(ui.ColorCyan)
 	emptyStyle := lipgloss.NewStyle().Foreground(ui.ColorDarkGray)
 	bar := progressStyle.Render(strings.Repeat("█", filled)) + emptyStyle.Render(strings.Repeat("░", barWidth-filled))
-	lines = append(lines, fmt.Sprintf("  %s %d/%d", bar, m.pullCurrentIdx, len(m.pullRepos)))
+	lines = append(lines, fmt.Sprintf("  %s %d/%d", bar, len(m.pullResults), len(m.pullRepos)))
 
 	content := strings.Join(lines, "\n")
 
@@ -2694,19 +2932,83 @@ func (m Model) renderPullProgress() string {
 	return boxStyle.Render(content)
 }
 
+// pullStillOn notes the branch a repo was left on when pull all didn't leave the pulled branch checked out
+func (m Model) pullStillOn(r models.PullResult) string {
+	if m.pullMode == config.PullModeCheckout || r.OriginalBranch == "" {
+		return ""
+	}
+	branch := r.OriginalBranch
+	if short := shortHash(branch); short != branch {
+		branch = "detached " + short
+	}
+	return " · on " + branch
+}
+
+// shortHash abbreviates a full commit hash, leaving anything else unchanged
+func shortHash(s string) string {
+	if len(s) == 40 && strings.Trim(s, "0123456789abcdef") == "" {
+		return s[:7]
+	}
+	return s
+}
+
+// renderPullResetConfirmation lists the branches that would be reset and the commits they'd lose
+func (m Model) renderPullResetConfirmation() string {
+	var lines []string
+
+	lines = append(lines, ui.SectionHeader("Reset to Origin", ui.ColorRed))
+	lines = append(lines, "")
+	lines = append(lines, fmt.Sprintf("   Branches to reset: %d", m.pullResettableCount()))
+	lines = append(lines, "")
+
+	repoStyle := lipgloss.NewStyle().Foreground(ui.ColorCyan)
+	dimStyle := lipgloss.NewStyle().Foreground(ui.ColorDarkGray)
+	for i := range m.pullRepos {
+		r, done := m.pullResults[i]
+		if !done || !r.CanResetToOrigin() {
+			continue
+		}
+		lines = append(lines, "   "+repoStyle.Render(r.Repo.DisplayName)+dimStyle.Render(fmt.Sprintf(" %s -> origin/%s, dropping %d commits:", r.Branch, r.Branch, r.Ahead)))
+		for _, c := range r.LocalCommits {
+			lines = append(lines, "      "+dimStyle.Render(c.Hash+" "+c.Message))
+		}
+		if more := r.Ahead - len(r.LocalCommits); more > 0 {
+			lines = append(lines, "      "+dimStyle.Render(fmt.Sprintf("... and %d more", more)))
+		}
+	}
+	lines = append(lines, "")
+
+	warnStyle := lipgloss.NewStyle().Foreground(ui.ColorRed).Bold(true)
+	lines = append(lines, warnStyle.Render("   ⚠ Local-only commits are dropped (recoverable from git reflog)"))
+	lines = append(lines, dimStyle.Render("   Checked out branches with local changes are left alone"))
+	lines = append(lines, "")
+
+	if m.dryRun {
+		warningStyle := lipgloss.NewStyle().Foreground(ui.ColorYellow).Bold(true)
+		lines = append(lines, warningStyle.Render("   ⚠ DRY RUN: No actual changes will be made"))
+		lines = append(lines, "")
+	}
+
+	lines = append(lines, ui.YesNoButtons(m.confirmSelection))
+
+	return strings.Join(lines, "\n")
+}
+
 func (m Model) renderPullSummaryWithHeight(availableHeight int) string {
 	var lines []string
 
 	// Header
-	branchStyle := lipgloss.NewStyle().Foreground(ui.BranchColor(m.pullBranch)).Bold(true)
+	branchStyle := lipgloss.NewStyle().Foreground(ui.StageColor(m.pullStage, len(m.config.ReleaseFlow().Stages))).Bold(true)
 	titleStyle := lipgloss.NewStyle().Foreground(ui.ColorGreen).Bold(true)
-	lines = append(lines, titleStyle.Render("Pull Complete: ")+branchStyle.Render(m.pullBranch))
+	lines = append(lines, titleStyle.Render("Pull Complete: ")+branchStyle.Render(m.pullBranch()))
 	lines = append(lines, "")
 
 	// Group results by status
 	resultsByStatus := map[models.PullStatus][]models.PullResult{}
-	for _, r := range m.pullResults {
-		resultsByStatus[r.Status] = append(resultsByStatus[r.Status], r)
+	for i := range m.pullRepos {
+		if r, done := m.pullResults[i]; done {
+			resultsByStatus[r.Status] = append(resultsByStatus[r.Status], r)
+		}
 	}
 
 	repoStyle := lipgloss.NewStyle().Foreground(ui.ColorCyan)
@@ -2719,7 +3021,16 @@ func (m Model) renderPullSummaryWithHeight(availableHeight int) string {
 	if updated := resultsByStatus[models.PullUpdated]; len(updated) > 0 {
 		lines = append(lines, greenStyle.Render(fmt.Sprintf("Updated (%d):", len(updated))))
 		for _, r := range updated {
-			lines = append(lines, "  "+repoStyle.Render(r.Repo.DisplayName)+dimStyle.Render(fmt.Sprintf(" (%d commits)", r.CommitCount)))
+			lines = append(lines, "  "+repoStyle.Render(r.Repo.DisplayName)+dimStyle.Render(fmt.Sprintf(" (%d commits)", r.CommitCount)+m.pullStillOn(r)))
+		}
+		lines = append(lines, "")
+	}
+
+	// Created from origin
+	if created := resultsByStatus[models.PullCreated]; len(created) > 0 {
+		lines = append(lines, greenStyle.Render(fmt.Sprintf("Created from origin (%d):", len(created))))
+		for _, r := range created {
+			lines = append(lines, "  "+repoStyle.Render(r.Repo.DisplayName)+dimStyle.Render(m.pullStillOn(r)))
 		}
 		lines = append(lines, "")
 	}
@@ -2728,7 +3039,7 @@ func (m Model) renderPullSummaryWithHeight(availableHeight int) string {
 	if upToDate := resultsByStatus[models.PullUpToDate]; len(upToDate) > 0 {
 		lines = append(lines, dimStyle.Render(fmt.Sprintf("Already up to date (%d):", len(upToDate))))
 		for _, r := range upToDate {
-			lines = append(lines, "  "+dimStyle.Render(r.Repo.DisplayName))
+			lines = append(lines, "  "+dimStyle.Render(r.Repo.DisplayName+m.pullStillOn(r)))
 		}
 		lines = append(lines, "")
 	}
@@ -2742,6 +3053,66 @@ func (m Model) renderPullSummaryWithHeight(availableHeight int) string {
 		lines = append(lines, "")
 	}
 
+	// Stashed and restored
+	if restored := resultsByStatus[models.PullStashRestored]; len(restored) > 0 {
+		lines = append(lines, greenStyle.Render(fmt.Sprintf("Stashed and restored (%d):", len(restored))))
+		for _, r := range restored {
+			lines = append(lines, "  "+repoStyle.Render(r.Repo.DisplayName)+dimStyle.Render(fmt.Sprintf(" (%d commits)", r.CommitCount)+m.pullStillOn(r)))
+		}
+		lines = append(lines, "")
+	}
+
+	// Stash conflict - changes left in the stash
+	if conflicts := resultsByStatus[models.PullStashConflict]; len(conflicts) > 0 {
+		lines = append(lines, yellowStyle.Render(fmt.Sprintf("Stash conflict - left stashed (%d):", len(conflicts))))
+		for _, r := range conflicts {
+			lines = append(lines, "  "+repoStyle.Render(r.Repo.DisplayName)+dimStyle.Render(fmt.Sprintf(" - %s (git stash pop %s)", r.StashRef, r.StashRef)))
+		}
+		lines = append(lines, "")
+	}
+
+	// Diverged and ahead: list the local-only commits so they can be pushed, rebased or dropped
+	localCommits := func(r models.PullResult) {
+		for _, c := range r.LocalCommits {
+			lines = append(lines, "      "+dimStyle.Render(c.Hash+" "+c.Message))
+		}
+		if more := r.Ahead - len(r.LocalCommits); more > 0 {
+			lines = append(lines, "      "+dimStyle.Render(fmt.Sprintf("... and %d more", more)))
+		}
+	}
+	if diverged := resultsByStatus[models.PullDiverged]; len(diverged) > 0 {
+		lines = append(lines, redStyle.Render(fmt.Sprintf("Diverged from origin (%d):", len(diverged))))
+		for _, r := range diverged {
+			lines = append(lines, "  "+repoStyle.Render(r.Repo.DisplayName)+dimStyle.Render(fmt.Sprintf(" %s: %d local, %d on origin", r.Branch, r.Ahead, r.Behind)))
+			localCommits(r)
+		}
+		lines = append(lines, dimStyle.Render("  Rebase onto origin/<branch> to keep local commits, or press r to reset to origin"))
+		lines = append(lines, "")
+	}
+	if ahead := resultsByStatus[models.PullAhead]; len(ahead) > 0 {
+		lines = append(lines, yellowStyle.Render(fmt.Sprintf("Ahead of origin (%d):", len(ahead))))
+		for _, r := range ahead {
+			lines = append(lines, "  "+repoStyle.Render(r.Repo.DisplayName)+dimStyle.Render(fmt.Sprintf(" %s: %d local commits not on origin", r.Branch, r.Ahead)))
+			localCommits(r)
+		}
+		lines = append(lines, dimStyle.Render("  Push them if they belong on origin, or press r to reset to origin"))
+		lines = append(lines, "")
+	}
+	if behind := resultsByStatus[models.PullBehind]; len(behind) > 0 {
+		lines = append(lines, redStyle.Render(fmt.Sprintf("Behind, couldn't fast-forward (%d):", len(behind))))
+		for _, r := range behind {
+			lines = append(lines, "  "+repoStyle.Render(r.Repo.DisplayName)+dimStyle.Render(fmt.Sprintf(" %s: %d on origin - %s", r.Branch, r.Behind, r.Error)))
+		}
+		lines = append(lines, "")
+	}
+	if reset := resultsByStatus[models.PullReset]; len(reset) > 0 {
+		lines = append(lines, greenStyle.Render(fmt.Sprintf("Reset to origin (%d):", len(reset))))
+		for _, r := range reset {
+			lines = append(lines, "  "+repoStyle.Render(r.Repo.DisplayName)+dimStyle.Render(fmt.Sprintf(" %s (was %s, see git reflog)", r.Branch, shortHash(r.ResetFrom))))
+		}
+		lines = append(lines, "")
+	}
+
 	// Skipped - dirty
 	if dirty := resultsByStatus[models.PullSkippedDirty]; len(dirty) > 0 {
 		lines = append(lines, yellowStyle.Render(fmt.Sprintf("Skipped - local changes (%d):", len(dirty))))
@@ -2765,7 +3136,8 @@ func (m Model) renderPullSummaryWithHeight(availableHeight int) string {
 
 	// Determine header color based on results
 	headerColor := ui.ColorGreen
-	if len(resultsByStatus[models.PullFailed]) > 0 {
+	if len(resultsByStatus[models.PullFailed]) > 0 || len(resultsByStatus[models.PullStashConflict]) > 0 ||
+		len(resultsByStatus[models.PullDiverged]) > 0 || len(resultsByStatus[models.PullBehind]) > 0 {
 		headerColor = ui.ColorYellow
 	}
 
@@ -5,19 +5,42 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/wahlandcase/attuned.prmanager/internal/models"
+	"github.com/wahlandcase/attuned.prmanager/internal/tracker"
+
 	"github.com/pelletier/go-toml/v2"
 )
 
 type Config struct {
 	Paths   PathsConfig   `toml:"paths"`
+	Flow    FlowConfig    `toml:"flow"`
 	Tickets TicketsConfig `toml:"tickets"`
-	Update  UpdateConfig  `toml:"update"`
+	Merge   MergeConfig   `toml:"merge"`
+	// MergeWait controls wait-for-checks merge mode (global only)
+	MergeWait MergeWaitConfig `toml:"merge_wait"`
+	Pull      PullConfig      `toml:"pull"`
+	// PR holds the release PR title and body templates
+	PR PRConfig `toml:"pr"`
+	// Sprint numbers sprints for PR templates
+	Sprint SprintConfig `toml:"sprint"`
+	// Changelog adds a conventional commit changelog to PR bodies
+	Changelog ChangelogConfig `toml:"changelog"`
+	// Concurrency limits how many repos are processed at once
+	Concurrency ConcurrencyConfig `toml:"concurrency"`
+	GitHub      GitHubConfig      `toml:"github"`
+	Update      UpdateConfig      `toml:"update"`
+
+	// Repos holds per-repo overrides keyed by display name (e.g., "backend/api-service")
+	Repos map[string]RepoConfig `toml:"repos,omitempty"`
 
 	// Compiled regex from Tickets.Pattern (not serialized)
 	ticketRegex *regexp.Regexp
+	// Tracker built from Tickets (not serialized)
+	ticketTracker models.TicketTracker
 }
 
 type UpdateConfig struct {
@@ -27,15 +50,171 @@ type UpdateConfig struct {
 	Repo           string    `toml:"repo"`
 }
 
+// GitHub backends selectable via github.backend
+const (
+	BackendGh  = "gh"  // Shell out to the gh CLI
+	BackendAPI = "api" // Call the GitHub API directly with a token
+)
+
+type GitHubConfig struct {
+	// Backend is "gh" (default) or "api"
+	Backend string `toml:"backend"`
+	// APIURL overrides the REST API base URL (for GitHub Enterprise)
+	APIURL string `toml:"api_url"`
+}
+
 type PathsConfig struct {
 	AttunedDir   string `toml:"attuned_dir"`
 	FrontendGlob string `toml:"frontend_glob"`
 	BackendGlob  string `toml:"backend_glob"`
+
+	// Groups replaces the frontend/backend globs when set
+	Groups []GroupConfig `toml:"groups,omitempty"`
+}
+
+type GroupConfig struct {
+	Name string `toml:"name"`
+	// Globs are relative to attuned_dir
+	Globs []string `toml:"globs,omitempty"`
+	// Paths are explicit repo paths (absolute, "~/", or relative to attuned_dir)
+	Paths []string `toml:"paths,omitempty"`
+}
+
+type MergeWaitConfig struct {
+	// Timeout is how long to wait for a PR's checks before giving up (e.g. "30m")
+	Timeout string `toml:"timeout"`
+	// PollInterval is how often workflow runs are checked (e.g. "15s")
+	PollInterval string `toml:"poll_interval"`
+}
+
+// Pull all modes selectable via pull.mode
+const (
+	PullModeCheckout = "checkout" // Check out the branch and pull, leaving it checked out
+	PullModeUpdate   = "update"   // Fast-forward the local branch without switching to it
+	PullModeRestore  = "restore"  // Check out the branch and pull, then switch back
+)
+
+// PullModes lists the pull all modes in the order the TUI cycles through them
+var PullModes = []string{PullModeCheckout, PullModeUpdate, PullModeRestore}
+
+type PullConfig struct {
+	// AutoStash stashes local changes before pulling and re-applies them afterwards,
+	// instead of skipping dirty repos (toggle with s before pulling)
+	AutoStash bool `toml:"auto_stash"`
+	// Mode is "checkout" (default), "update" or "restore" (cycle with b before pulling)
+	Mode string `toml:"mode"`
+}
+
+func (p PullConfig) validate() error {
+	if p.Mode != "" && !slices.Contains(PullModes, p.Mode) {
+		return fmt.Errorf("mode must be one of %s, got %q", strings.Join(PullModes, ", "), p.Mode)
+	}
+	return nil
+}
+
+// ModeOrDefault returns the pull mode ("checkout" if unset)
+func (p PullConfig) ModeOrDefault() string {
+	if p.Mode == "" {
+		return PullModeCheckout
+	}
+	return p.Mode
+}
+
+type ConcurrencyConfig struct {
+	// Batch is how many repos batch mode creates PRs in at once
+	Batch int `toml:"batch"`
+	// Pull is how many repos pull all updates at once
+	Pull int `toml:"pull"`
+}
+
+const (
+	defaultBatchConcurrency = 4
+	defaultPullConcurrency  = 8
+)
+
+func (c ConcurrencyConfig) validate() error {
+	if c.Batch < 0 {
+		return fmt.Errorf("batch must not be negative")
+	}
+	if c.Pull < 0 {
+		return fmt.Errorf("pull must not be negative")
+	}
+	return nil
+}
+
+// BatchOrDefault returns the batch concurrency (4 if unset)
+func (c ConcurrencyConfig) BatchOrDefault() int {
+	if c.Batch > 0 {
+		return c.Batch
+	}
+	return defaultBatchConcurrency
+}
+
+// PullOrDefault returns the pull all concurrency (8 if unset)
+func (c ConcurrencyConfig) PullOrDefault() int {
+	if c.Pull > 0 {
+		return c.Pull
+	}
+	return defaultPullConcurrency
+}
+
+type ChangelogConfig struct {
+	// Enabled adds a changelog grouped by conventional commit type after the tickets
+	// (toggle with Tab on the commit review screen)
+	Enabled bool `toml:"enabled"`
+}
+
+type FlowConfig struct {
+	// Stages are the release branches in promotion order. "main" matches main or master.
+	Stages []string `toml:"stages"`
 }
 
 type TicketsConfig struct {
 	Pattern   string `toml:"pattern"`
 	LinearOrg string `toml:"linear_org"`
+	// Tracker is "linear" (default), "jira" or "github"
+	Tracker string `toml:"tracker,omitempty"`
+	// JiraURL is the Jira site tickets link to (jira tracker)
+	JiraURL string `toml:"jira_url,omitempty"`
+	// GitHubRepo is the "owner/name" repo issues live in (github tracker, default the PR's repo)
+	GitHubRepo string `toml:"github_repo,omitempty"`
+	// ClosingKeyword is written before each ticket (default "Closes" for linear and github,
+	// none for jira; "none" turns it off)
+	ClosingKeyword string `toml:"closing_keyword,omitempty"`
+}
+
+// overlay returns t with every field set in over replacing its own
+func (t TicketsConfig) overlay(over TicketsConfig) TicketsConfig {
+	if over.Pattern != "" {
+		t.Pattern = over.Pattern
+	}
+	if over.LinearOrg != "" {
+		t.LinearOrg = over.LinearOrg
+	}
+	if over.Tracker != "" {
+		t.Tracker = over.Tracker
+	}
+	if over.JiraURL != "" {
+		t.JiraURL = over.JiraURL
+	}
+	if over.GitHubRepo != "" {
+		t.GitHubRepo = over.GitHubRepo
+	}
+	if over.ClosingKeyword != "" {
+		t.ClosingKeyword = over.ClosingKeyword
+	}
+	return t
+}
+
+// tracker builds the ticket tracker the settings describe
+func (t TicketsConfig) tracker() (models.TicketTracker, error) {
+	return tracker.New(tracker.Options{
+		Kind:       t.Tracker,
+		LinearOrg:  t.LinearOrg,
+		JiraURL:    t.JiraURL,
+		GitHubRepo: t.GitHubRepo,
+		Keyword:    t.ClosingKeyword,
+	})
 }
 
 func DefaultConfig() *Config {
@@ -45,9 +224,30 @@ func DefaultConfig() *Config {
 			FrontendGlob: "frontend/*",
 			BackendGlob:  "backend/*",
 		},
+		Flow: FlowConfig{
+			Stages: models.DefaultFlow().Stages,
+		},
 		Tickets: TicketsConfig{
 			Pattern:   "ATT-[0-9]+",
 			LinearOrg: "attuned",
+			Tracker:   tracker.KindLinear,
+		},
+		Merge: MergeConfig{
+			Strategy: string(models.MergeStrategyMerge),
+		},
+		MergeWait: MergeWaitConfig{
+			Timeout:      defaultMergeWaitTimeout.String(),
+			PollInterval: defaultMergeWaitPollInterval.String(),
+		},
+		Pull: PullConfig{
+			Mode: PullModeCheckout,
+		},
+		Concurrency: ConcurrencyConfig{
+			Batch: defaultBatchConcurrency,
+			Pull:  defaultPullConcurrency,
+		},
+		GitHub: GitHubConfig{
+			Backend: BackendGh,
 		},
 		Update: UpdateConfig{
 			Enabled: true,
@@ -101,16 +301,149 @@ func Load() (*Config, error) {
 		return nil, err
 	}
 
+	if cfg.ticketTracker, err = cfg.Tickets.tracker(); err != nil {
+		return nil, fmt.Errorf("invalid tickets: %w", err)
+	}
+
+	if err := cfg.ReleaseFlow().Validate(); err != nil {
+		return nil, fmt.Errorf("invalid flow.stages: %w", err)
+	}
+
+	if err := cfg.Merge.validate(); err != nil {
+		return nil, fmt.Errorf("invalid merge: %w", err)
+	}
+	if err := validateStageKeys(cfg.Merge.Stages, cfg.flows()); err != nil {
+		return nil, fmt.Errorf("invalid merge: %w", err)
+	}
+
+	if err := cfg.MergeWait.validate(); err != nil {
+		return nil, fmt.Errorf("invalid merge_wait: %w", err)
+	}
+
+	if err := cfg.PR.validate(); err != nil {
+		return nil, fmt.Errorf("invalid pr: %w", err)
+	}
+	if err := validateStageKeys(cfg.PR.Stages, cfg.flows()); err != nil {
+		return nil, fmt.Errorf("invalid pr: %w", err)
+	}
+
+	if err := cfg.Sprint.validate(); err != nil {
+		return nil, fmt.Errorf("invalid sprint: %w", err)
+	}
+
+	if err := cfg.Pull.validate(); err != nil {
+		return nil, fmt.Errorf("invalid pull: %w", err)
+	}
+
+	if err := cfg.Concurrency.validate(); err != nil {
+		return nil, fmt.Errorf("invalid concurrency: %w", err)
+	}
+
+	if err := cfg.validateRepos(); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.validateGroups(); err != nil {
+		return nil, err
+	}
+
 	return cfg, nil
 }
 
+// RepoGroups returns the configured repo groups, or frontend/backend from the legacy globs
+func (c *Config) RepoGroups() []models.RepoGroup {
+	var groups []models.RepoGroup
+	if len(c.Paths.Groups) > 0 {
+		for _, g := range c.Paths.Groups {
+			groups = append(groups, models.RepoGroup{Name: g.Name, Globs: g.Globs, Paths: g.Paths})
+		}
+		return groups
+	}
+	if c.Paths.FrontendGlob != "" {
+		groups = append(groups, models.RepoGroup{Name: "frontend", Globs: []string{c.Paths.FrontendGlob}})
+	}
+	if c.Paths.BackendGlob != "" {
+		groups = append(groups, models.RepoGroup{Name: "backend", Globs: []string{c.Paths.BackendGlob}})
+	}
+	return groups
+}
+
+// DiscoveryGroups returns the groups in the order discovered repos are listed. Configured
+// groups keep their order; the legacy globs list backend repos before frontend as they always have
+func (c *Config) DiscoveryGroups() []models.RepoGroup {
+	groups := c.RepoGroups()
+	if len(c.Paths.Groups) == 0 {
+		slices.SortStableFunc(groups, func(a, b models.RepoGroup) int { return strings.Compare(a.Name, b.Name) })
+	}
+	return groups
+}
+
+func (c *Config) validateGroups() error {
+	seen := make(map[string]bool)
+	for i, g := range c.Paths.Groups {
+		if g.Name == "" || strings.Contains(g.Name, "/") {
+			return fmt.Errorf("invalid paths.groups[%d]: name must be non-empty and contain no \"/\"", i)
+		}
+		if seen[g.Name] {
+			return fmt.Errorf("invalid paths.groups: %q appears more than once", g.Name)
+		}
+		seen[g.Name] = true
+		if len(g.Globs) == 0 && len(g.Paths) == 0 {
+			return fmt.Errorf("invalid paths.groups %q: needs at least one glob or path", g.Name)
+		}
+	}
+	return nil
+}
+
+// ReleaseFlow returns the global release flow
+func (c *Config) ReleaseFlow() models.Flow {
+	if len(c.Flow.Stages) == 0 {
+		return models.DefaultFlow()
+	}
+	return models.Flow{Stages: c.Flow.Stages}
+}
+
+// flows returns the global flow and every flow set in [repos]
+func (c *Config) flows() []models.Flow {
+	flows := []models.Flow{c.ReleaseFlow()}
+	for _, repo := range c.Repos {
+		if len(repo.Flow.Stages) > 0 {
+			flows = append(flows, models.Flow{Stages: repo.Flow.Stages})
+		}
+	}
+	return flows
+}
+
+// validateStageKeys rejects [*.stages] overrides that aren't a promotion in any of the flows
+func validateStageKeys[T any](stages map[string]T, flows []models.Flow) error {
+	var slugs []string
+	for _, flow := range flows {
+		for _, prType := range flow.PrTypes() {
+			if !slices.Contains(slugs, prType.Slug()) {
+				slugs = append(slugs, prType.Slug())
+			}
+		}
+	}
+	keys := make([]string, 0, len(stages))
+	for slug := range stages {
+		keys = append(keys, slug)
+	}
+	slices.Sort(keys)
+	for _, slug := range keys {
+		if !slices.Contains(slugs, slug) {
+			return fmt.Errorf("stages.%q: not a promotion in the flow (expected one of %s)", slug, strings.Join(slugs, ", "))
+		}
+	}
+	return nil
+}
+
 func (c *Config) compileRegex() error {
 	// Empty pattern = ticket extraction disabled
 	if c.Tickets.Pattern == "" {
 		c.ticketRegex = nil
 		return nil
 	}
-	re, err := regexp.Compile("(?i)(" + c.Tickets.Pattern + ")")
+	re, err := compileTicketPattern(c.Tickets.Pattern)
 	if err != nil {
 		return fmt.Errorf("invalid tickets.pattern %q: %w", c.Tickets.Pattern, err)
 	}
@@ -118,6 +451,24 @@ func (c *Config) compileRegex() error {
 	return nil
 }
 
+// compileTicketPattern compiles a ticket pattern for case-insensitive matching
+func compileTicketPattern(pattern string) (*regexp.Regexp, error) {
+	return regexp.Compile("(?i)(" + pattern + ")")
+}
+
+// Tracker returns the global ticket tracker
+func (c *Config) Tracker() models.TicketTracker {
+	if c.ticketTracker != nil {
+		return c.ticketTracker
+	}
+	// Not loaded through Load (e.g., the default config): build it now, falling back to Linear
+	t, err := c.Tickets.tracker()
+	if err != nil {
+		t, _ = tracker.New(tracker.Options{LinearOrg: c.Tickets.LinearOrg})
+	}
+	return t
+}
+
 // TicketRegex returns the compiled ticket pattern regex (nil if disabled)
 func (c *Config) TicketRegex() *regexp.Regexp {
 	// Safe even if compileRegex() was never called
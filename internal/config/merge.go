@@ -0,0 +1,168 @@
+package config
+
+import (
+	"fmt"
+	"text/template"
+	"time"
+
+	"github.com/wahlandcase/attuned.prmanager/internal/models"
+)
+
+// MergeConfig controls how release PRs are merged. It is read from [merge] in attpr.toml and
+// from [merge] in per-repo overrides. Later layers win field by field in this order: global,
+// global stage, repo, repo stage.
+type MergeConfig struct {
+	// Strategy is "merge" (default), "squash" or "rebase"
+	Strategy string `toml:"strategy,omitempty"`
+	// DeleteBranch deletes the head branch after merging (default false)
+	DeleteBranch *bool `toml:"delete_branch,omitempty"`
+	// SubjectTemplate and BodyTemplate are text/templates for the merge commit.
+	// Fields: .Title, .Number, .Repo, .Head, .Base
+	SubjectTemplate string `toml:"subject_template,omitempty"`
+	BodyTemplate    string `toml:"body_template,omitempty"`
+	// Stages overrides the above per promotion, keyed "<head>-<base>" (e.g., "dev-staging").
+	// Keys must be a promotion in the global flow or a [repos] flow (or the repo's flow for
+	// repo overrides).
+	Stages map[string]MergeConfig `toml:"stages,omitempty"`
+}
+
+// overlay returns m with every field set in over replacing its own (stages are merged by key)
+func (m MergeConfig) overlay(over MergeConfig) MergeConfig {
+	if over.Strategy != "" {
+		m.Strategy = over.Strategy
+	}
+	if over.DeleteBranch != nil {
+		m.DeleteBranch = over.DeleteBranch
+	}
+	if over.SubjectTemplate != "" {
+		m.SubjectTemplate = over.SubjectTemplate
+	}
+	if over.BodyTemplate != "" {
+		m.BodyTemplate = over.BodyTemplate
+	}
+	if len(over.Stages) > 0 {
+		stages := make(map[string]MergeConfig, len(m.Stages)+len(over.Stages))
+		for k, v := range m.Stages {
+			stages[k] = v
+		}
+		for k, v := range over.Stages {
+			stages[k] = stages[k].overlay(v)
+		}
+		m.Stages = stages
+	}
+	return m
+}
+
+// base returns the settings without stage overrides
+func (m MergeConfig) base() MergeConfig {
+	m.Stages = nil
+	return m
+}
+
+// forPromotion returns the settings for one promotion, with its stage override applied
+func (m MergeConfig) forPromotion(slug string) MergeConfig {
+	return m.base().overlay(m.Stages[slug])
+}
+
+// validate checks the strategy names and templates, including every stage override
+func (m MergeConfig) validate() error {
+	if _, err := m.method(); err != nil {
+		return err
+	}
+	for slug, stage := range m.Stages {
+		if len(stage.Stages) > 0 {
+			return fmt.Errorf("stages.%q: stages can't be nested", slug)
+		}
+		if _, err := stage.method(); err != nil {
+			return fmt.Errorf("stages.%q: %w", slug, err)
+		}
+	}
+	return nil
+}
+
+// method compiles the settings into a merge method (ignoring stage overrides)
+func (m MergeConfig) method() (models.MergeMethod, error) {
+	var method models.MergeMethod
+	strategy, ok := models.ParseMergeStrategy(m.Strategy)
+	if !ok {
+		return method, fmt.Errorf("strategy %q (expected merge, squash or rebase)", m.Strategy)
+	}
+	method.Strategy = strategy
+	if m.DeleteBranch != nil {
+		method.DeleteBranch = *m.DeleteBranch
+	}
+	if m.SubjectTemplate != "" {
+		tmpl, err := template.New("subject").Option("missingkey=error").Parse(m.SubjectTemplate)
+		if err != nil {
+			return method, fmt.Errorf("subject_template: %w", err)
+		}
+		method.SubjectTemplate = tmpl
+	}
+	if m.BodyTemplate != "" {
+		tmpl, err := template.New("body").Option("missingkey=error").Parse(m.BodyTemplate)
+		if err != nil {
+			return method, fmt.Errorf("body_template: %w", err)
+		}
+		method.BodyTemplate = tmpl
+	}
+	return method, nil
+}
+
+// mergeSettings resolves a repo's merge method for every promotion in its flow
+func (c *Config) mergeSettings(repo MergeConfig, flow models.Flow) (models.MergeSettings, error) {
+	var s models.MergeSettings
+	var err error
+	if s.Default, err = c.Merge.base().overlay(repo.base()).method(); err != nil {
+		return s, fmt.Errorf("merge: %w", err)
+	}
+	s.Promotions = make(map[string]models.MergeMethod)
+	for _, prType := range flow.PrTypes() {
+		slug := prType.Slug()
+		method, err := c.Merge.forPromotion(slug).overlay(repo.forPromotion(slug)).method()
+		if err != nil {
+			return s, fmt.Errorf("merge.stages.%q: %w", slug, err)
+		}
+		s.Promotions[slug] = method
+	}
+	return s, nil
+}
+
+// Wait-for-checks defaults
+const (
+	defaultMergeWaitTimeout      = 30 * time.Minute
+	defaultMergeWaitPollInterval = 15 * time.Second
+)
+
+// validate checks both durations parse and are positive
+func (w MergeWaitConfig) validate() error {
+	for key, value := range map[string]string{"timeout": w.Timeout, "poll_interval": w.PollInterval} {
+		if value == "" {
+			continue
+		}
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("%s %q: %w", key, value, err)
+		}
+		if d <= 0 {
+			return fmt.Errorf("%s %q must be positive", key, value)
+		}
+	}
+	return nil
+}
+
+// TimeoutOrDefault returns the configured timeout (30m if unset)
+func (w MergeWaitConfig) TimeoutOrDefault() time.Duration {
+	return parseDurationOr(w.Timeout, defaultMergeWaitTimeout)
+}
+
+// PollIntervalOrDefault returns the configured poll interval (15s if unset)
+func (w MergeWaitConfig) PollIntervalOrDefault() time.Duration {
+	return parseDurationOr(w.PollInterval, defaultMergeWaitPollInterval)
+}
+
+func parseDurationOr(value string, fallback time.Duration) time.Duration {
+	if d, err := time.ParseDuration(value); err == nil && d > 0 {
+		return d
+	}
+	return fallback
+}
@@ -0,0 +1,153 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/wahlandcase/attuned.prmanager/internal/models"
+)
+
+// PRConfig holds the release PR title and body templates. It is read from [pr] in attpr.toml
+// and from [pr] in per-repo overrides. Later layers win field by field in this order: global,
+// global stage, repo, repo stage.
+type PRConfig struct {
+	// TitleTemplate and BodyTemplate are text/templates for the PR title and body.
+	// Fields: .Title, .Repo, .Head, .Base, .Commits, .Tickets, .Authors, .Date, .Sprint,
+	// .DefaultBody. Empty uses the entered title and the default tickets list.
+	TitleTemplate string `toml:"title_template,omitempty"`
+	BodyTemplate  string `toml:"body_template,omitempty"`
+	// Stages overrides the above per promotion, keyed "<head>-<base>" (e.g., "staging-main").
+	// Keys must be a promotion in the global flow or a [repos] flow (or the repo's flow for
+	// repo overrides).
+	Stages map[string]PRConfig `toml:"stages,omitempty"`
+}
+
+// overlay returns p with every field set in over replacing its own (stages are merged by key)
+func (p PRConfig) overlay(over PRConfig) PRConfig {
+	if over.TitleTemplate != "" {
+		p.TitleTemplate = over.TitleTemplate
+	}
+	if over.BodyTemplate != "" {
+		p.BodyTemplate = over.BodyTemplate
+	}
+	if len(over.Stages) > 0 {
+		stages := make(map[string]PRConfig, len(p.Stages)+len(over.Stages))
+		for k, v := range p.Stages {
+			stages[k] = v
+		}
+		for k, v := range over.Stages {
+			stages[k] = stages[k].overlay(v)
+		}
+		p.Stages = stages
+	}
+	return p
+}
+
+// base returns the templates without stage overrides
+func (p PRConfig) base() PRConfig {
+	p.Stages = nil
+	return p
+}
+
+// forPromotion returns the templates for one promotion, with its stage override applied
+func (p PRConfig) forPromotion(slug string) PRConfig {
+	return p.base().overlay(p.Stages[slug])
+}
+
+// validate checks the templates parse, including every stage override
+func (p PRConfig) validate() error {
+	if _, err := p.template(); err != nil {
+		return err
+	}
+	for slug, stage := range p.Stages {
+		if len(stage.Stages) > 0 {
+			return fmt.Errorf("stages.%q: stages can't be nested", slug)
+		}
+		if _, err := stage.template(); err != nil {
+			return fmt.Errorf("stages.%q: %w", slug, err)
+		}
+	}
+	return nil
+}
+
+// prTemplateFuncs are available to PR templates in addition to the text/template builtins
+var prTemplateFuncs = template.FuncMap{
+	"join": strings.Join,
+}
+
+// template compiles the title and body templates (ignoring stage overrides)
+func (p PRConfig) template() (models.PRTemplate, error) {
+	var t models.PRTemplate
+	if p.TitleTemplate != "" {
+		tmpl, err := template.New("title").Funcs(prTemplateFuncs).Option("missingkey=error").Parse(p.TitleTemplate)
+		if err != nil {
+			return t, fmt.Errorf("title_template: %w", err)
+		}
+		t.Title = tmpl
+	}
+	if p.BodyTemplate != "" {
+		tmpl, err := template.New("body").Funcs(prTemplateFuncs).Option("missingkey=error").Parse(p.BodyTemplate)
+		if err != nil {
+			return t, fmt.Errorf("body_template: %w", err)
+		}
+		t.Body = tmpl
+	}
+	return t, nil
+}
+
+// prTemplates resolves a repo's PR templates for every promotion in its flow
+func (c *Config) prTemplates(repo PRConfig, flow models.Flow) (models.PRTemplates, error) {
+	var s models.PRTemplates
+	var err error
+	if s.Default, err = c.PR.base().overlay(repo.base()).template(); err != nil {
+		return s, fmt.Errorf("pr: %w", err)
+	}
+	s.Promotions = make(map[string]models.PRTemplate)
+	for _, prType := range flow.PrTypes() {
+		slug := prType.Slug()
+		t, err := c.PR.forPromotion(slug).overlay(repo.forPromotion(slug)).template()
+		if err != nil {
+			return s, fmt.Errorf("pr.stages.%q: %w", slug, err)
+		}
+		s.Promotions[slug] = t
+	}
+	return s, nil
+}
+
+// SprintConfig numbers sprints for PR templates (.Sprint)
+type SprintConfig struct {
+	// Start is the first day of sprint 1 (YYYY-MM-DD)
+	Start string `toml:"start,omitempty"`
+	// Days is the sprint length (default 14)
+	Days int `toml:"days,omitempty"`
+}
+
+const defaultSprintDays = 14
+
+func (s SprintConfig) validate() error {
+	if s.Start != "" {
+		if _, err := time.Parse(time.DateOnly, s.Start); err != nil {
+			return fmt.Errorf("start %q must be a date like 2026-01-05", s.Start)
+		}
+	}
+	if s.Days < 0 {
+		return fmt.Errorf("days must not be negative")
+	}
+	return nil
+}
+
+// Number returns the sprint that contains t (0 if no start is configured or t is before it)
+func (s SprintConfig) Number(t time.Time) int {
+	start, err := time.ParseInLocation(time.DateOnly, s.Start, t.Location())
+	if err != nil || t.Before(start) {
+		return 0
+	}
+	days := s.Days
+	if days <= 0 {
+		days = defaultSprintDays
+	}
+	elapsed := int(t.Sub(start).Hours() / 24)
+	return elapsed/days + 1
+}
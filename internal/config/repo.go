@@ -0,0 +1,261 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/wahlandcase/attuned.prmanager/internal/models"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+// RepoFileName is the optional per-repo config file read from a repo's root
+const RepoFileName = ".attpr.toml"
+
+// RepoConfig overrides global settings for one repo. It is read from [repos."<display name>"]
+// in attpr.toml and from the repo's own .attpr.toml (attpr.toml wins field by field).
+type RepoConfig struct {
+	// Exclude hides the repo from discovery (batch, open PRs, pull all, actions)
+	Exclude *bool `toml:"exclude,omitempty"`
+	// MainBranch overrides the detected main branch
+	MainBranch string `toml:"main_branch,omitempty"`
+	// Flow overrides the release flow
+	Flow FlowConfig `toml:"flow,omitempty"`
+	// TicketPattern overrides tickets.pattern (shorthand for tickets.pattern, which wins if both are set)
+	TicketPattern string `toml:"ticket_pattern,omitempty"`
+	// Tickets overrides [tickets] (pattern, tracker and its settings)
+	Tickets TicketsConfig `toml:"tickets,omitempty"`
+	// TitleTemplate is a text/template for PR titles, e.g. "[api] {{.Title}}".
+	// Shorthand for pr.title_template (which wins if both are set).
+	TitleTemplate string `toml:"title_template,omitempty"`
+	// PR overrides [pr] (title and body templates, per-stage templates)
+	PR PRConfig `toml:"pr,omitempty"`
+	// Labels are added to every PR created or updated
+	Labels []string `toml:"labels,omitempty"`
+	// Reviewers are requested on every PR (users, or "org/team" for teams)
+	Reviewers []string `toml:"reviewers,omitempty"`
+	// Merge overrides [merge] (strategy, delete_branch, templates, per-stage settings)
+	Merge MergeConfig `toml:"merge,omitempty"`
+	// MergeAfter lists repos whose PRs for the same promotion must merge before this repo's
+	MergeAfter []string `toml:"merge_after,omitempty"`
+	// DeployWorkflow is the workflow that deploys this repo's branches. Repos that merge after
+	// this one wait for its run on the base branch to succeed.
+	DeployWorkflow string `toml:"deploy_workflow,omitempty"`
+}
+
+// merge returns r with every field set in over replacing its own
+func (r RepoConfig) merge(over RepoConfig) RepoConfig {
+	if over.Exclude != nil {
+		r.Exclude = over.Exclude
+	}
+	if over.MainBranch != "" {
+		r.MainBranch = over.MainBranch
+	}
+	if len(over.Flow.Stages) > 0 {
+		r.Flow = over.Flow
+	}
+	if over.TicketPattern != "" {
+		r.TicketPattern = over.TicketPattern
+	}
+	r.Tickets = r.Tickets.overlay(over.Tickets)
+	if over.TitleTemplate != "" {
+		r.TitleTemplate = over.TitleTemplate
+	}
+	r.PR = r.PR.overlay(over.PR)
+	if len(over.Labels) > 0 {
+		r.Labels = over.Labels
+	}
+	if len(over.Reviewers) > 0 {
+		r.Reviewers = over.Reviewers
+	}
+	r.Merge = r.Merge.overlay(over.Merge)
+	if len(over.MergeAfter) > 0 {
+		r.MergeAfter = over.MergeAfter
+	}
+	if over.DeployWorkflow != "" {
+		r.DeployWorkflow = over.DeployWorkflow
+	}
+	return r
+}
+
+// validate checks the overrides can be applied
+func (r RepoConfig) validate() error {
+	if len(r.Flow.Stages) > 0 {
+		if err := (models.Flow{Stages: r.Flow.Stages}).Validate(); err != nil {
+			return fmt.Errorf("flow.stages: %w", err)
+		}
+	}
+	if err := r.Merge.validate(); err != nil {
+		return fmt.Errorf("merge: %w", err)
+	}
+	if err := r.prConfig().validate(); err != nil {
+		return fmt.Errorf("pr: %w", err)
+	}
+	_, err := r.settings()
+	return err
+}
+
+// validateStages checks the merge and PR stage overrides are promotions in the repo's flow
+func (r RepoConfig) validateStages(flow models.Flow) error {
+	if err := validateStageKeys(r.Merge.Stages, []models.Flow{flow}); err != nil {
+		return fmt.Errorf("merge: %w", err)
+	}
+	if err := validateStageKeys(r.PR.Stages, []models.Flow{flow}); err != nil {
+		return fmt.Errorf("pr: %w", err)
+	}
+	return nil
+}
+
+// settings compiles the ticket pattern
+func (r RepoConfig) settings() (models.RepoSettings, error) {
+	s := models.RepoSettings{
+		Labels:         r.Labels,
+		Reviewers:      r.Reviewers,
+		MergeAfter:     r.MergeAfter,
+		DeployWorkflow: r.DeployWorkflow,
+	}
+	if pattern := r.ticketPattern(); pattern != "" {
+		re, err := compileTicketPattern(pattern)
+		if err != nil {
+			return s, fmt.Errorf("ticket_pattern %q: %w", pattern, err)
+		}
+		s.TicketRegex = re
+	}
+	return s, nil
+}
+
+// ticketPattern returns the repo's ticket pattern ("" = use global)
+func (r RepoConfig) ticketPattern() string {
+	if r.Tickets.Pattern != "" {
+		return r.Tickets.Pattern
+	}
+	return r.TicketPattern
+}
+
+// prConfig returns the repo's PR templates, with title_template as the repo-level title
+func (r RepoConfig) prConfig() PRConfig {
+	return PRConfig{TitleTemplate: r.TitleTemplate}.overlay(r.PR)
+}
+
+// validateRepos checks every [repos."<name>"] table
+func (c *Config) validateRepos() error {
+	for name, repo := range c.Repos {
+		if err := repo.validate(); err != nil {
+			return fmt.Errorf("invalid repos.%q: %w", name, err)
+		}
+		if _, err := c.Tickets.overlay(repo.Tickets).tracker(); err != nil {
+			return fmt.Errorf("invalid repos.%q: tickets: %w", name, err)
+		}
+		// Without its own flow the repo may take one from .attpr.toml, checked when it's resolved
+		if len(repo.Flow.Stages) > 0 {
+			if err := repo.validateStages(models.Flow{Stages: repo.Flow.Stages}); err != nil {
+				return fmt.Errorf("invalid repos.%q: %w", name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// repoConfig looks up overrides for a repo. Keys match the full display name, or just the
+// last segment for repos detected outside the attuned dir (single mode uses the folder name).
+// A folder name matching more than one key is an error rather than a guess.
+func (c *Config) repoConfig(displayName string) (RepoConfig, bool, error) {
+	if repo, ok := c.Repos[displayName]; ok {
+		return repo, true, nil
+	}
+	if strings.Contains(displayName, "/") {
+		return RepoConfig{}, false, nil
+	}
+	var keys []string
+	for key := range c.Repos {
+		if path.Base(key) == displayName {
+			keys = append(keys, key)
+		}
+	}
+	switch len(keys) {
+	case 0:
+		return RepoConfig{}, false, nil
+	case 1:
+		return c.Repos[keys[0]], true, nil
+	}
+	slices.Sort(keys)
+	return RepoConfig{}, false, fmt.Errorf("ambiguous repo %q matches [repos] keys %s; use the full name", displayName, strings.Join(keys, ", "))
+}
+
+// LoadRepoFile reads a repo's .attpr.toml (zero RepoConfig if it has none)
+func LoadRepoFile(repoPath string) (RepoConfig, error) {
+	var rc RepoConfig
+	data, err := os.ReadFile(filepath.Join(repoPath, RepoFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return rc, nil
+		}
+		return rc, err
+	}
+	if err := toml.Unmarshal(data, &rc); err != nil {
+		return rc, fmt.Errorf("%s: %w", filepath.Join(repoPath, RepoFileName), err)
+	}
+	if err := rc.validate(); err != nil {
+		return rc, fmt.Errorf("%s: %w", filepath.Join(repoPath, RepoFileName), err)
+	}
+	return rc, nil
+}
+
+// RepoConfigFor returns the merged overrides for a repo: its .attpr.toml, then attpr.toml
+func (c *Config) RepoConfigFor(repo models.RepoInfo) (RepoConfig, error) {
+	rc, err := LoadRepoFile(repo.Path)
+	if err != nil {
+		return RepoConfig{}, err
+	}
+	over, ok, err := c.repoConfig(repo.DisplayName)
+	if err != nil {
+		return RepoConfig{}, err
+	}
+	if ok {
+		rc = rc.merge(over)
+	}
+	return rc, nil
+}
+
+// ResolveRepo applies per-repo overrides to a discovered repo. Returns false if the repo is excluded.
+func (c *Config) ResolveRepo(repo *models.RepoInfo) (bool, error) {
+	rc, err := c.RepoConfigFor(*repo)
+	if err != nil {
+		return false, err
+	}
+
+	if rc.MainBranch != "" {
+		repo.MainBranch = rc.MainBranch
+	}
+	repo.Flow = c.ReleaseFlow()
+	if len(rc.Flow.Stages) > 0 {
+		repo.Flow = models.Flow{Stages: rc.Flow.Stages}
+	}
+	repo.Settings, err = rc.settings()
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", repo.DisplayName, err)
+	}
+	if err := rc.validateStages(repo.Flow); err != nil {
+		return false, fmt.Errorf("%s: %w", repo.DisplayName, err)
+	}
+	repo.Settings.Merge, err = c.mergeSettings(rc.Merge, repo.Flow)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", repo.DisplayName, err)
+	}
+	repo.Settings.PR, err = c.prTemplates(rc.prConfig(), repo.Flow)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", repo.DisplayName, err)
+	}
+	if rc.Tickets != (TicketsConfig{}) {
+		repo.Settings.Tracker, err = c.Tickets.overlay(rc.Tickets).tracker()
+		if err != nil {
+			return false, fmt.Errorf("%s: tickets: %w", repo.DisplayName, err)
+		}
+	}
+
+	return rc.Exclude == nil || !*rc.Exclude, nil
+}
@@ -0,0 +1,118 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/wahlandcase/attuned.prmanager/internal/models"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+func TestResolveRepoExclude(t *testing.T) {
+	tests := []struct {
+		name     string
+		repoFile string // .attpr.toml contents
+		global   string // [repos."backend/api"] table in attpr.toml
+		included bool
+	}{
+		{name: "not set", included: true},
+		{name: "excluded by repo file", repoFile: "exclude = true", included: false},
+		{name: "excluded by attpr.toml", global: "exclude = true", included: false},
+		{name: "attpr.toml re-includes", repoFile: "exclude = true", global: "exclude = false", included: true},
+		{name: "attpr.toml excludes over repo file", repoFile: "exclude = false", global: "exclude = true", included: false},
+		{name: "unset in attpr.toml keeps repo file", repoFile: "exclude = true", global: `main_branch = "prod"`, included: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if tt.repoFile != "" {
+				if err := os.WriteFile(filepath.Join(dir, RepoFileName), []byte(tt.repoFile), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			cfg := DefaultConfig()
+			if tt.global != "" {
+				if err := toml.Unmarshal([]byte("[repos.\"backend/api\"]\n"+tt.global), cfg); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			repo := models.NewRepoInfo(dir, "backend/api", "main")
+			included, err := cfg.ResolveRepo(&repo)
+			if err != nil {
+				t.Fatalf("ResolveRepo: %v", err)
+			}
+			if included != tt.included {
+				t.Errorf("included = %v, want %v", included, tt.included)
+			}
+		})
+	}
+}
+
+func TestDiscoveryGroups(t *testing.T) {
+	cfg := DefaultConfig()
+	if got := groupNames(cfg.DiscoveryGroups()); got != "backend,frontend" {
+		t.Errorf("legacy discovery order = %s, want backend first", got)
+	}
+	if got := groupNames(cfg.RepoGroups()); got != "frontend,backend" {
+		t.Errorf("legacy display order = %s, want frontend first", got)
+	}
+
+	cfg.Paths.Groups = []GroupConfig{{Name: "web", Globs: []string{"web/*"}}, {Name: "api", Globs: []string{"api/*"}}}
+	if got := groupNames(cfg.DiscoveryGroups()); got != "web,api" {
+		t.Errorf("configured discovery order = %s, want config order", got)
+	}
+}
+
+func groupNames(groups []models.RepoGroup) string {
+	var names []string
+	for _, g := range groups {
+		names = append(names, g.Name)
+	}
+	return strings.Join(names, ",")
+}
+
+func TestResolveRepoByFolderName(t *testing.T) {
+	web := RepoConfig{MainBranch: "prod"}
+	tests := []struct {
+		name    string
+		repos   map[string]RepoConfig
+		main    string
+		wantErr string
+	}{
+		{name: "no match", repos: map[string]RepoConfig{"backend/api": web}, main: "main"},
+		{name: "one match", repos: map[string]RepoConfig{"frontend/web": web}, main: "prod"},
+		{
+			name:    "ambiguous",
+			repos:   map[string]RepoConfig{"frontend/web": web, "backend/web": {MainBranch: "master"}},
+			wantErr: `ambiguous repo "web" matches [repos] keys backend/web, frontend/web; use the full name`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.Repos = tt.repos
+
+			// Single mode names the repo after its folder
+			repo := models.NewRepoInfo(t.TempDir(), "web", "main")
+			_, err := cfg.ResolveRepo(&repo)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("ResolveRepo error = %v, want %s", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ResolveRepo: %v", err)
+			}
+			if repo.MainBranch != tt.main {
+				t.Errorf("main branch = %q, want %q", repo.MainBranch, tt.main)
+			}
+		})
+	}
+}
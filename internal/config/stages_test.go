@@ -0,0 +1,122 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/wahlandcase/attuned.prmanager/internal/models"
+)
+
+// loadConfig writes attpr.toml into a temp config dir and loads it
+func loadConfig(t *testing.T, contents string) (*Config, error) {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("HOME", dir)
+	if err := os.WriteFile(filepath.Join(dir, "attpr.toml"), []byte(contents), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return Load()
+}
+
+func TestLoadStageKeys(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		wantErr string
+	}{
+		{
+			name:   "stage in the flow",
+			config: "[pr.stages.staging-main]\ntitle_template = \"Sprint {{.Sprint}}\"",
+		},
+		{
+			name:    "typo",
+			config:  "[pr.stages.stagin-main]\ntitle_template = \"Sprint {{.Sprint}}\"",
+			wantErr: `invalid pr: stages."stagin-main": not a promotion in the flow (expected one of dev-staging, staging-main)`,
+		},
+		{
+			name:   "stage in a repo flow",
+			config: "[pr.stages.qa-main]\ntitle_template = \"QA\"\n\n[repos.\"backend/api\".flow]\nstages = [\"dev\", \"qa\", \"main\"]",
+		},
+		{
+			name:    "repo stage outside its flow",
+			config:  "[repos.\"backend/api\".flow]\nstages = [\"dev\", \"qa\", \"main\"]\n\n[repos.\"backend/api\".pr.stages.staging-main]\ntitle_template = \"Sprint\"",
+			wantErr: `invalid repos."backend/api": pr: stages."staging-main": not a promotion in the flow (expected one of dev-qa, qa-main)`,
+		},
+		{
+			name:    "merge typo",
+			config:  "[merge.stages.dev-stagin]\nstrategy = \"squash\"",
+			wantErr: `invalid merge: stages."dev-stagin": not a promotion in the flow (expected one of dev-staging, staging-main)`,
+		},
+		{
+			name:   "merge stage in a repo flow",
+			config: "[merge.stages.dev-qa]\nstrategy = \"squash\"\n\n[repos.\"backend/api\".flow]\nstages = [\"dev\", \"qa\", \"main\"]",
+		},
+		{
+			name:    "repo merge stage outside its flow",
+			config:  "[repos.\"backend/api\".flow]\nstages = [\"dev\", \"qa\", \"main\"]\n\n[repos.\"backend/api\".merge.stages.dev-staging]\nstrategy = \"squash\"",
+			wantErr: `invalid repos."backend/api": merge: stages."dev-staging": not a promotion in the flow (expected one of dev-qa, qa-main)`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := loadConfig(t, tt.config)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Load: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("Load error = %v, want %s", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestResolveRepoStageKeys(t *testing.T) {
+	tests := []struct {
+		name     string
+		repoFile string
+		wantErr  string
+	}{
+		{
+			name:     "pr typo",
+			repoFile: "[pr.stages.dev-stagin]\ntitle_template = \"Staging\"",
+			wantErr:  `backend/api: pr: stages."dev-stagin"`,
+		},
+		{
+			name:     "merge typo",
+			repoFile: "[merge.stages.dev-stagin]\nstrategy = \"squash\"",
+			wantErr:  `backend/api: merge: stages."dev-stagin"`,
+		},
+		{
+			name:     "stage in the repo's own flow",
+			repoFile: "[flow]\nstages = [\"dev\", \"qa\", \"main\"]\n\n[merge.stages.qa-main]\nstrategy = \"squash\"",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, RepoFileName), []byte(tt.repoFile), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			repo := models.NewRepoInfo(dir, "backend/api", "main")
+			_, err := DefaultConfig().ResolveRepo(&repo)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ResolveRepo: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ResolveRepo error = %v, want %s", err, tt.wantErr)
+			}
+		})
+	}
+}
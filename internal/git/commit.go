@@ -1,15 +1,14 @@
 package git
 
 import (
+	"errors"
+	"os/exec"
 	"regexp"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/wahlandcase/attuned.prmanager/internal/models"
-
-	"github.com/go-git/go-git/v5"
-	"github.com/go-git/go-git/v5/plumbing"
-	"github.com/go-git/go-git/v5/plumbing/object"
 )
 
 // ExtractTickets extracts ticket IDs from text using the given compiled regex
@@ -39,67 +38,63 @@ func ExtractTickets(text string, ticketRegex *regexp.Regexp) []string {
 }
 
 // GetCommitsBetween gets commits between two branches (base..head)
-// Returns commits that are in head but not in base
+// Returns commits that are in head but not in base, newest first in topological order.
+// git stops walking at the merge base, so only the new commits are read.
 func GetCommitsBetween(repoPath, baseBranch, headBranch string, ticketRegex *regexp.Regexp) ([]models.CommitInfo, error) {
-	repo, err := git.PlainOpen(repoPath)
-	if err != nil {
-		return nil, err
-	}
-
 	baseRef := "refs/remotes/origin/" + baseBranch
 	headRef := "refs/remotes/origin/" + headBranch
 
-	baseHash, err := repo.ResolveRevision(plumbing.Revision(baseRef))
-	if err != nil {
+	if _, err := revParse(repoPath, baseRef); err != nil {
 		return nil, &BranchNotFoundError{Branches: []string{baseBranch}}
 	}
-
-	headHash, err := repo.ResolveRevision(plumbing.Revision(headRef))
-	if err != nil {
+	if _, err := revParse(repoPath, headRef); err != nil {
 		return nil, &BranchNotFoundError{Branches: []string{headBranch}}
 	}
 
-	// Build set of commits reachable from base
-	baseCommits := make(map[plumbing.Hash]bool)
-	baseIter, err := repo.Log(&git.LogOptions{From: *baseHash})
-	if err != nil {
-		return nil, err
-	}
-	baseIter.ForEach(func(c *object.Commit) error {
-		baseCommits[c.Hash] = true
-		return nil
-	})
-
-	// Get commits from head that are not in base
-	headIter, err := repo.Log(&git.LogOptions{From: *headHash})
+	// Fields and commits (-z) are NUL separated, as git doesn't allow NUL in commit messages
+	cmd := exec.Command("git", "log", "-z", "--topo-order", "--format=%H%x00%an%x00%aI%x00%P%x00%B", baseRef+".."+headRef)
+	cmd.Dir = repoPath
+	output, err := cmd.Output()
 	if err != nil {
-		return nil, err
+		return nil, &GitError{Command: "log", Output: commandOutput(err)}
 	}
 
 	var commits []models.CommitInfo
-	seen := make(map[plumbing.Hash]bool)
-	err = headIter.ForEach(func(c *object.Commit) error {
-		// Skip if already processed or reachable from base.
-		// Don't stop iteration - merge commits have multiple parents
-		// and we need to traverse all paths to find feature commits.
-		if seen[c.Hash] || baseCommits[c.Hash] {
-			return nil
-		}
-		seen[c.Hash] = true
-
-		hash := c.Hash.String()[:7]
-		message := strings.Split(c.Message, "\n")[0]      // First line for display
-		tickets := ExtractTickets(c.Message, ticketRegex) // Full message for tickets
+	fields := strings.Split(string(output), "\x00")
+	for i := 0; i+5 <= len(fields); i += 5 {
+		hash, author, date, parents, body := fields[i], fields[i+1], fields[i+2], fields[i+3], fields[i+4]
+
+		message := strings.Split(body, "\n")[0]      // First line for display
+		tickets := ExtractTickets(body, ticketRegex) // Full message for tickets
+
+		commit := models.NewCommitInfo(hash[:7], message, tickets)
+		commit.Author = author
+		commit.Date, _ = time.Parse(time.RFC3339, date)
+		commit.Parents = len(strings.Fields(parents))
+		commit.Breaking = hasBreakingFooter(body)
+		commits = append(commits, commit)
+	}
 
-		commits = append(commits, models.NewCommitInfo(hash, message, tickets))
-		return nil
-	})
+	return commits, nil
+}
 
-	if err != nil {
-		return nil, err
+// hasBreakingFooter checks a commit message for a conventional commit BREAKING CHANGE footer
+func hasBreakingFooter(message string) bool {
+	for _, line := range strings.Split(message, "\n")[1:] {
+		if strings.HasPrefix(line, "BREAKING CHANGE:") || strings.HasPrefix(line, "BREAKING-CHANGE:") {
+			return true
+		}
 	}
+	return false
+}
 
-	return commits, nil
+// commandOutput returns the stderr captured by exec.Cmd.Output, or the error itself
+func commandOutput(err error) string {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+		return strings.TrimSpace(string(exitErr.Stderr))
+	}
+	return err.Error()
 }
 
 // GetAllTickets gets all unique tickets from a list of commits
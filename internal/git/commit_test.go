@@ -0,0 +1,233 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// testRepo builds commit graphs directly in a bare repo, without a working tree
+type testRepo struct {
+	tb   testing.TB
+	path string
+	repo *gogit.Repository
+	tree plumbing.Hash
+	now  time.Time
+}
+
+func newTestRepo(tb testing.TB) *testRepo {
+	tb.Helper()
+	path := tb.TempDir()
+	repo, err := gogit.PlainInit(path, true)
+	if err != nil {
+		tb.Fatalf("init repo: %v", err)
+	}
+	r := &testRepo{tb: tb, path: path, repo: repo, now: time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)}
+	r.tree = r.store(&object.Tree{})
+	return r
+}
+
+type encoder interface {
+	Encode(plumbing.EncodedObject) error
+}
+
+func (r *testRepo) store(obj encoder) plumbing.Hash {
+	r.tb.Helper()
+	encoded := r.repo.Storer.NewEncodedObject()
+	if err := obj.Encode(encoded); err != nil {
+		r.tb.Fatalf("encode object: %v", err)
+	}
+	hash, err := r.repo.Storer.SetEncodedObject(encoded)
+	if err != nil {
+		r.tb.Fatalf("store object: %v", err)
+	}
+	return hash
+}
+
+// commit creates a commit with the given parents, one minute after the previous one
+func (r *testRepo) commit(message string, parents ...plumbing.Hash) plumbing.Hash {
+	r.tb.Helper()
+	r.now = r.now.Add(time.Minute)
+	sig := object.Signature{Name: "Dev", Email: "dev@example.com", When: r.now}
+	return r.store(&object.Commit{
+		Author:       sig,
+		Committer:    sig,
+		Message:      message,
+		TreeHash:     r.tree,
+		ParentHashes: parents,
+	})
+}
+
+// chain creates n linear commits on top of parent, returning the last
+func (r *testRepo) chain(parent plumbing.Hash, prefix string, n int) plumbing.Hash {
+	for i := 1; i <= n; i++ {
+		parent = r.commit(fmt.Sprintf("%s %d\n", prefix, i), parent)
+	}
+	return parent
+}
+
+// origin points refs/remotes/origin/<branch> at a commit
+func (r *testRepo) origin(branch string, hash plumbing.Hash) {
+	r.tb.Helper()
+	ref := plumbing.NewHashReference(plumbing.NewRemoteReferenceName("origin", branch), hash)
+	if err := r.repo.Storer.SetReference(ref); err != nil {
+		r.tb.Fatalf("set ref: %v", err)
+	}
+}
+
+func TestGetCommitsBetween(t *testing.T) {
+	ticketRegex := regexp.MustCompile(`(?i)\b(ATT-\d+)\b`)
+
+	tests := []struct {
+		name     string
+		setup    func(r *testRepo)
+		messages []string
+		parents  []int
+		tickets  [][]string
+		breaking []bool
+		missing  []string
+	}{
+		{
+			name: "linear history newest first",
+			setup: func(r *testRepo) {
+				root := r.commit("initial\n")
+				r.origin("main", root)
+				a := r.commit("feat: add login ATT-1\n", root)
+				b := r.commit("fix: login typo\n", a)
+				r.origin("dev", b)
+			},
+			messages: []string{"fix: login typo", "feat: add login ATT-1"},
+			parents:  []int{1, 1},
+			tickets:  [][]string{nil, {"ATT-1"}},
+			breaking: []bool{false, false},
+		},
+		{
+			name: "merge commit",
+			setup: func(r *testRepo) {
+				root := r.commit("initial\n")
+				r.origin("main", root)
+				feature := r.commit("feat: feature work ATT-2\n", root)
+				other := r.commit("chore: other work\n", root)
+				merge := r.commit("Merge branch 'feature' into dev\n", other, feature)
+				r.origin("dev", merge)
+			},
+			messages: []string{"Merge branch 'feature' into dev", "feat: feature work ATT-2", "chore: other work"},
+			parents:  []int{2, 1, 1},
+			tickets:  [][]string{nil, {"ATT-2"}, nil},
+			breaking: []bool{false, false, false},
+		},
+		{
+			name: "base ahead of head",
+			setup: func(r *testRepo) {
+				root := r.commit("initial\n")
+				r.origin("dev", root)
+				r.origin("main", r.chain(root, "release", 3))
+			},
+		},
+		{
+			name: "multi-line body with separator characters",
+			setup: func(r *testRepo) {
+				root := r.commit("initial\n")
+				r.origin("main", root)
+				body := "feat: new parser\n\nHandles %x1e and %x1f format verbs,\nand raw \x1e and \x1f bytes.\n\nRefs ATT-7\nBREAKING CHANGE: drops the old parser\n"
+				c := r.commit(body, root)
+				r.origin("dev", r.commit("fix: follow-up ATT-8\n", c))
+			},
+			messages: []string{"fix: follow-up ATT-8", "feat: new parser"},
+			parents:  []int{1, 1},
+			tickets:  [][]string{{"ATT-8"}, {"ATT-7"}},
+			breaking: []bool{false, true},
+		},
+		{
+			name: "missing head branch",
+			setup: func(r *testRepo) {
+				r.origin("main", r.commit("initial\n"))
+			},
+			missing: []string{"dev"},
+		},
+		{
+			name: "missing base branch",
+			setup: func(r *testRepo) {
+				r.origin("dev", r.commit("initial\n"))
+			},
+			missing: []string{"main"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRepo(t)
+			tt.setup(r)
+
+			commits, err := GetCommitsBetween(r.path, "main", "dev", ticketRegex)
+			if tt.missing != nil {
+				var notFound *BranchNotFoundError
+				if !errors.As(err, &notFound) {
+					t.Fatalf("expected BranchNotFoundError, got %v", err)
+				}
+				if fmt.Sprint(notFound.Branches) != fmt.Sprint(tt.missing) {
+					t.Errorf("missing branches = %v, want %v", notFound.Branches, tt.missing)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(commits) != len(tt.messages) {
+				t.Fatalf("got %d commits, want %d: %+v", len(commits), len(tt.messages), commits)
+			}
+			for i, c := range commits {
+				if c.Message != tt.messages[i] {
+					t.Errorf("commit %d message = %q, want %q", i, c.Message, tt.messages[i])
+				}
+				if c.Parents != tt.parents[i] {
+					t.Errorf("commit %d parents = %d, want %d", i, c.Parents, tt.parents[i])
+				}
+				if c.IsMerge() != (tt.parents[i] > 1) {
+					t.Errorf("commit %d IsMerge = %v", i, c.IsMerge())
+				}
+				if fmt.Sprint(c.Tickets) != fmt.Sprint(tt.tickets[i]) {
+					t.Errorf("commit %d tickets = %v, want %v", i, c.Tickets, tt.tickets[i])
+				}
+				if c.Breaking != tt.breaking[i] {
+					t.Errorf("commit %d breaking = %v, want %v", i, c.Breaking, tt.breaking[i])
+				}
+				if len(c.Hash) != 7 {
+					t.Errorf("commit %d hash = %q, want 7 characters", i, c.Hash)
+				}
+				if c.Author != "Dev" {
+					t.Errorf("commit %d author = %q, want %q", i, c.Author, "Dev")
+				}
+				if c.Date.IsZero() {
+					t.Errorf("commit %d has no date", i)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkGetCommitsBetween(b *testing.B) {
+	r := newTestRepo(b)
+	base := r.chain(r.commit("initial\n"), "chore: history", 20000)
+	r.origin("main", base)
+	r.origin("dev", r.chain(base, "feat: change ATT-1", 50))
+	ticketRegex := regexp.MustCompile(`(?i)\b(ATT-\d+)\b`)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		commits, err := GetCommitsBetween(r.path, "main", "dev", ticketRegex)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if len(commits) != 50 {
+			b.Fatalf("got %d commits, want 50", len(commits))
+		}
+	}
+}
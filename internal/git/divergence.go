@@ -0,0 +1,86 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/wahlandcase/attuned.prmanager/internal/models"
+)
+
+// maxLocalCommits caps how many local-only commits Divergence lists
+const maxLocalCommits = 20
+
+// Divergence compares a local branch with origin's copy
+type Divergence struct {
+	Ahead        int                 // Commits only on the local branch
+	Behind       int                 // Commits only on origin
+	LocalCommits []models.CommitInfo // Local-only commits, newest first (at most 20)
+}
+
+// BranchDivergence compares a local branch with origin's copy (fetched beforehand)
+func BranchDivergence(repoPath, branch string) (Divergence, error) {
+	local, remote := "refs/heads/"+branch, "refs/remotes/origin/"+branch
+
+	cmd := exec.Command("git", "rev-list", "--left-right", "--count", local+"..."+remote)
+	cmd.Dir = repoPath
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return Divergence{}, &GitError{Command: "rev-list", Output: strings.TrimSpace(string(output))}
+	}
+	var d Divergence
+	if _, err := fmt.Sscanf(strings.TrimSpace(string(output)), "%d %d", &d.Ahead, &d.Behind); err != nil {
+		return Divergence{}, &GitError{Command: "rev-list", Output: strings.TrimSpace(string(output))}
+	}
+	if d.Ahead == 0 {
+		return d, nil
+	}
+
+	cmd = exec.Command("git", "log", fmt.Sprintf("--max-count=%d", maxLocalCommits), "--format=%h%x09%s", remote+".."+local)
+	cmd.Dir = repoPath
+	output, err = cmd.CombinedOutput()
+	if err != nil {
+		return Divergence{}, &GitError{Command: "log", Output: strings.TrimSpace(string(output))}
+	}
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		hash, message, _ := strings.Cut(line, "\t")
+		if hash != "" {
+			d.LocalCommits = append(d.LocalCommits, models.CommitInfo{Hash: hash, Message: message})
+		}
+	}
+	return d, nil
+}
+
+// ResetToOrigin points a local branch at origin's copy, dropping local-only commits, and
+// returns its previous commit (still reachable through the reflog). A checked out branch
+// must have no local changes.
+func ResetToOrigin(repoPath, branch string) (string, error) {
+	previous, err := revParse(repoPath, "refs/heads/"+branch)
+	if err != nil {
+		return "", &GitError{Command: "rev-parse", Output: "no local branch " + branch}
+	}
+
+	current, err := CurrentBranch(repoPath)
+	if err != nil {
+		return "", err
+	}
+
+	var cmd *exec.Cmd
+	if current == branch {
+		dirty, err := IsDirty(repoPath)
+		if err != nil {
+			return "", err
+		}
+		if dirty {
+			return "", &GitError{Command: "reset", Output: "local changes on " + branch + "; commit or stash them first"}
+		}
+		cmd = exec.Command("git", "reset", "--hard", "refs/remotes/origin/"+branch)
+	} else {
+		cmd = exec.Command("git", "branch", "--force", branch, "refs/remotes/origin/"+branch)
+	}
+	cmd.Dir = repoPath
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return "", &GitError{Command: "reset", Output: strings.TrimSpace(string(output))}
+	}
+	return previous, nil
+}
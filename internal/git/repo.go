@@ -43,9 +43,17 @@ func GetCurrentRepoInfo() (*models.RepoInfo, error) {
 	if err != nil {
 		return nil, err
 	}
+	return GetRepoInfoFromDir(cwd)
+}
+
+// GetRepoInfoFromDir gets info for the repository containing dir
+func GetRepoInfoFromDir(dir string) (*models.RepoInfo, error) {
+	path, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
 
 	// Walk up to find git root
-	path := cwd
 	for {
 		if IsGitRepo(path) {
 			break
@@ -153,27 +161,35 @@ func (e *BranchNotFoundError) Error() string {
 	return "Branch not found on remote: " + strings.Join(e.Branches, ", ")
 }
 
-// FindAttunedRepos finds all git repositories in the attuned directory structure
-func FindAttunedRepos(basePath, frontendGlob, backendGlob string) ([]models.RepoInfo, error) {
+// RepoResolver applies per-repo settings to a discovered repo, returning false to exclude it
+type RepoResolver func(repo *models.RepoInfo) (bool, error)
+
+// FindAttunedRepos finds all git repositories in the given groups, in group order.
+// If resolve is non-nil it is applied to every repo found.
+func FindAttunedRepos(basePath string, groups []models.RepoGroup, resolve RepoResolver) ([]models.RepoInfo, error) {
 	var repos []models.RepoInfo
+	seen := make(map[string]bool) // Repos matched by more than one glob/path are listed once
 
-	// Process each glob pattern with its category name
-	globs := []struct {
-		category string
-		pattern  string
-	}{
-		{"frontend", frontendGlob},
-		{"backend", backendGlob},
-	}
+	groupOrder := make(map[string]int)
+	for gi, g := range groups {
+		groupOrder[g.Name] = gi
 
-	for _, g := range globs {
-		pattern := filepath.Join(basePath, g.pattern)
-		matches, err := filepath.Glob(pattern)
-		if err != nil {
-			return nil, fmt.Errorf("invalid %s glob %q: %w", g.category, g.pattern, err)
+		var candidates []string
+		for _, pattern := range g.Globs {
+			matches, err := filepath.Glob(filepath.Join(basePath, pattern))
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s glob %q: %w", g.Name, pattern, err)
+			}
+			candidates = append(candidates, matches...)
+		}
+		for _, p := range g.Paths {
+			candidates = append(candidates, resolveGroupPath(basePath, p))
 		}
 
-		for _, path := range matches {
+		for _, path := range candidates {
+			if seen[path] {
+				continue
+			}
 			info, err := os.Stat(path)
 			if err != nil || !info.IsDir() {
 				continue
@@ -182,10 +198,11 @@ func FindAttunedRepos(basePath, frontendGlob, backendGlob string) ([]models.Repo
 			repoName := filepath.Base(path)
 
 			if IsGitRepo(path) {
-				displayName := g.category + "/" + repoName
+				seen[path] = true
+				displayName := g.Name + "/" + repoName
 
 				// Check for nested git repos inside this repo (like attuned-services)
-				nestedRepos := findNestedRepos(path, g.category, repoName)
+				nestedRepos := findNestedRepos(path, g.Name, repoName)
 
 				if len(nestedRepos) > 0 {
 					// This is a parent repo with nested repos - add the nested ones
@@ -193,6 +210,7 @@ func FindAttunedRepos(basePath, frontendGlob, backendGlob string) ([]models.Repo
 				} else {
 					// Regular repo, add it directly
 					if repoInfo, err := GetRepoInfo(path, displayName); err == nil {
+						repoInfo.Group = g.Name
 						repos = append(repos, *repoInfo)
 					}
 				}
@@ -200,20 +218,31 @@ func FindAttunedRepos(basePath, frontendGlob, backendGlob string) ([]models.Repo
 		}
 	}
 
-	// Sort: group by category (frontend/backend), then nested repos at end of category, then by name
+	// Apply per-repo settings, dropping excluded repos
+	if resolve != nil {
+		kept := repos[:0]
+		for _, r := range repos {
+			keep, err := resolve(&r)
+			if err != nil {
+				return nil, err
+			}
+			if keep {
+				kept = append(kept, r)
+			}
+		}
+		repos = kept
+	}
+
+	// Sort: by group (config order), then nested repos at end of group, then by name
 	sort.Slice(repos, func(i, j int) bool {
 		a, b := repos[i], repos[j]
 
-		// Extract category from display_name (first part)
-		catA := strings.Split(a.DisplayName, "/")[0]
-		catB := strings.Split(b.DisplayName, "/")[0]
-
-		// First sort by category
-		if catA != catB {
-			return catA < catB
+		// First sort by group
+		if a.Group != b.Group {
+			return groupOrder[a.Group] < groupOrder[b.Group]
 		}
 
-		// Within same category: non-nested repos first, then nested repos grouped by parent
+		// Within same group: non-nested repos first, then nested repos grouped by parent
 		if a.ParentRepo == nil && b.ParentRepo != nil {
 			return true // non-nested before nested
 		}
@@ -232,6 +261,25 @@ func FindAttunedRepos(basePath, frontendGlob, backendGlob string) ([]models.Repo
 	return repos, nil
 }
 
+// RemoteURL returns the first configured URL of the named remote
+func RemoteURL(repoPath, remoteName string) (string, error) {
+	repo, err := git.PlainOpen(repoPath)
+	if err != nil {
+		return "", err
+	}
+
+	remote, err := repo.Remote(remoteName)
+	if err != nil {
+		return "", fmt.Errorf("remote %q: %w", remoteName, err)
+	}
+
+	urls := remote.Config().URLs
+	if len(urls) == 0 {
+		return "", fmt.Errorf("remote %q has no URL", remoteName)
+	}
+	return urls[0], nil
+}
+
 // HasBranch checks if a branch exists (locally or remote)
 func HasBranch(repoPath, branch string) bool {
 	repo, err := git.PlainOpen(repoPath)
@@ -269,55 +317,126 @@ func IsDirty(repoPath string) (bool, error) {
 	return len(strings.TrimSpace(string(output))) > 0, nil
 }
 
-// CheckoutAndPull checks out the branch and pulls, returning commit count
-func CheckoutAndPull(repoPath, branch string) (int, error) {
+// CurrentBranch returns the checked out branch, or the commit hash when HEAD is detached
+// (either can be passed to Checkout to return to it)
+func CurrentBranch(repoPath string) (string, error) {
+	cmd := exec.Command("git", "rev-parse", "--abbrev-ref", "HEAD")
+	cmd.Dir = repoPath
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", &GitError{Command: "rev-parse", Output: strings.TrimSpace(string(output))}
+	}
+	if branch := strings.TrimSpace(string(output)); branch != "HEAD" {
+		return branch, nil
+	}
+
+	cmd = exec.Command("git", "rev-parse", "HEAD")
+	cmd.Dir = repoPath
+	output, err = cmd.CombinedOutput()
+	if err != nil {
+		return "", &GitError{Command: "rev-parse", Output: strings.TrimSpace(string(output))}
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// FastForwardBranch moves a local branch that isn't checked out up to origin's copy
+// (fetched beforehand), returning the number of new commits. created is true if the branch
+// only existed on origin and was created locally. Fails if it has diverged.
+func FastForwardBranch(repoPath, branch string) (commits int, created bool, err error) {
+	before, _ := revParse(repoPath, "refs/heads/"+branch) // Empty if the branch is only on origin
+
+	// Fetching from the repo itself updates the ref without touching the working tree
+	cmd := exec.Command("git", "fetch", ".", "refs/remotes/origin/"+branch+":refs/heads/"+branch)
+	cmd.Dir = repoPath
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return 0, false, &GitError{Command: "fetch", Output: strings.TrimSpace(string(output))}
+	}
+
+	if before == "" {
+		return 0, true, nil
+	}
+	cmd = exec.Command("git", "rev-list", "--count", before+".."+"refs/heads/"+branch)
+	cmd.Dir = repoPath
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return 0, false, &GitError{Command: "rev-list", Output: strings.TrimSpace(string(output))}
+	}
+	var count int
+	fmt.Sscanf(strings.TrimSpace(string(output)), "%d", &count)
+	return count, false, nil
+}
+
+// revParse resolves a ref to a commit hash
+func revParse(repoPath, ref string) (string, error) {
+	cmd := exec.Command("git", "rev-parse", "--verify", "--quiet", ref)
+	cmd.Dir = repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// Checkout switches to a branch
+func Checkout(repoPath, branch string) error {
+	cmd := exec.Command("git", "checkout", branch)
+	cmd.Dir = repoPath
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return &GitError{Command: "checkout", Output: strings.TrimSpace(string(output))}
+	}
+	return nil
+}
+
+// CheckoutAndPull checks out the branch and pulls, returning commit count. created is true if
+// the branch only existed on origin and checking it out created it locally.
+func CheckoutAndPull(repoPath, branch string) (commits int, created bool, err error) {
 	// Get current commit before pull
 	repo, err := git.PlainOpen(repoPath)
 	if err != nil {
-		return 0, err
+		return 0, false, err
 	}
+	_, err = revParse(repoPath, "refs/heads/"+branch)
+	created = err != nil
 
 	// Use git CLI for checkout (better SSH agent handling)
-	checkoutCmd := exec.Command("git", "checkout", branch)
-	checkoutCmd.Dir = repoPath
-	if output, err := checkoutCmd.CombinedOutput(); err != nil {
-		return 0, &GitError{Command: "checkout", Output: strings.TrimSpace(string(output))}
+	if err := Checkout(repoPath, branch); err != nil {
+		return 0, false, err
 	}
 
 	// Get HEAD before pull
 	headBefore, err := repo.Head()
 	if err != nil {
-		return 0, err
+		return 0, false, err
 	}
 
 	// Use git CLI for pull (better SSH agent handling)
 	pullCmd := exec.Command("git", "pull", "--ff-only")
 	pullCmd.Dir = repoPath
 	if output, err := pullCmd.CombinedOutput(); err != nil {
-		return 0, &GitError{Command: "pull", Output: strings.TrimSpace(string(output))}
+		return 0, false, &GitError{Command: "pull", Output: strings.TrimSpace(string(output))}
 	}
 
 	// Re-open repo and get HEAD after pull
 	repo, err = git.PlainOpen(repoPath)
 	if err != nil {
-		return 0, err
+		return 0, false, err
 	}
 
 	headAfter, err := repo.Head()
 	if err != nil {
-		return 0, err
+		return 0, false, err
 	}
 
 	// If same commit, no changes
 	if headBefore.Hash() == headAfter.Hash() {
-		return 0, nil
+		return 0, created, nil
 	}
 
 	// Count commits between old and new HEAD
 	commitCount := 0
 	iter, err := repo.Log(&git.LogOptions{From: headAfter.Hash()})
 	if err != nil {
-		return 0, err
+		return 0, false, err
 	}
 
 	iter.ForEach(func(c *object.Commit) error {
@@ -328,7 +447,20 @@ func CheckoutAndPull(repoPath, branch string) (int, error) {
 		return nil
 	})
 
-	return commitCount, nil
+	return commitCount, created, nil
+}
+
+// resolveGroupPath expands "~/" and makes relative paths relative to basePath
+func resolveGroupPath(basePath, path string) string {
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, rest)
+		}
+	}
+	if filepath.IsAbs(path) {
+		return filepath.Clean(path)
+	}
+	return filepath.Join(basePath, path)
 }
 
 // findNestedRepos finds nested git repos inside a parent repo (like attuned-services)
@@ -357,6 +489,7 @@ func findNestedRepos(parentPath, subdir, parentName string) []models.RepoInfo {
 
 			if repoInfo, err := GetRepoInfo(path, displayName); err == nil {
 				info := repoInfo.WithParent(parentName)
+				info.Group = subdir
 				nested = append(nested, info)
 			}
 		}
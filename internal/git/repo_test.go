@@ -0,0 +1,49 @@
+package git
+
+import (
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+func TestFastForwardBranch(t *testing.T) {
+	tests := []struct {
+		name    string
+		local   int // Commits on the local branch (-1 = no local branch)
+		origin  int // Commits on origin's branch
+		commits int
+		created bool
+	}{
+		{name: "created from origin", local: -1, origin: 3, created: true},
+		{name: "fast-forwarded", local: 1, origin: 4, commits: 3},
+		{name: "up to date", local: 2, origin: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRepo(t)
+			hashes := []plumbing.Hash{r.commit("initial\n")}
+			for i := 1; i < tt.origin; i++ {
+				hashes = append(hashes, r.commit("change\n", hashes[i-1]))
+			}
+			r.origin("dev", hashes[tt.origin-1])
+			if tt.local >= 0 {
+				ref := plumbing.NewHashReference(plumbing.NewBranchReferenceName("dev"), hashes[tt.local-1])
+				if err := r.repo.Storer.SetReference(ref); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			commits, created, err := FastForwardBranch(r.path, "dev")
+			if err != nil {
+				t.Fatalf("FastForwardBranch: %v", err)
+			}
+			if commits != tt.commits || created != tt.created {
+				t.Errorf("FastForwardBranch = (%d, %v), want (%d, %v)", commits, created, tt.commits, tt.created)
+			}
+			if head, _ := revParse(r.path, "refs/heads/dev"); head != hashes[tt.origin-1].String() {
+				t.Errorf("dev = %s, want origin's %s", head, hashes[tt.origin-1])
+			}
+		})
+	}
+}
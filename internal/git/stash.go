@@ -0,0 +1,77 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// StashConflictError means a stash couldn't be re-applied cleanly and was kept
+type StashConflictError struct {
+	Ref    string // e.g. "stash@{0}"
+	Output string
+}
+
+func (e *StashConflictError) Error() string {
+	return fmt.Sprintf("stash conflict, changes left in %s: %s", e.Ref, e.Output)
+}
+
+// Stash stashes local changes, including untracked files, and returns the stash commit
+func Stash(repoPath, message string) (string, error) {
+	cmd := exec.Command("git", "stash", "push", "--include-untracked", "-m", message)
+	cmd.Dir = repoPath
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return "", &GitError{Command: "stash", Output: strings.TrimSpace(string(output))}
+	}
+
+	cmd = exec.Command("git", "rev-parse", "stash@{0}")
+	cmd.Dir = repoPath
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", &GitError{Command: "rev-parse", Output: strings.TrimSpace(string(output))}
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// StashPop re-applies a stash commit from Stash and drops it. If it doesn't apply cleanly,
+// tracked files are reset to HEAD and the stash is kept (returns *StashConflictError).
+func StashPop(repoPath, stash string) error {
+	ref, err := stashRef(repoPath, stash)
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.Command("git", "stash", "pop", ref)
+	cmd.Dir = repoPath
+	output, err := cmd.CombinedOutput()
+	if err == nil {
+		return nil
+	}
+
+	// Undo the partial apply; the changes are still in the stash
+	reset := exec.Command("git", "reset", "--hard", "HEAD")
+	reset.Dir = repoPath
+	_ = reset.Run()
+
+	// Look the ref up again in case the pop shifted the stash list
+	if current, err := stashRef(repoPath, stash); err == nil {
+		ref = current
+	}
+	return &StashConflictError{Ref: ref, Output: strings.TrimSpace(string(output))}
+}
+
+// stashRef finds the stash@{n} entry for a stash commit
+func stashRef(repoPath, stash string) (string, error) {
+	cmd := exec.Command("git", "stash", "list", "--format=%H")
+	cmd.Dir = repoPath
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", &GitError{Command: "stash list", Output: strings.TrimSpace(string(output))}
+	}
+	for i, hash := range strings.Fields(string(output)) {
+		if hash == stash {
+			return fmt.Sprintf("stash@{%d}", i), nil
+		}
+	}
+	return "", &GitError{Command: "stash", Output: "stash " + stash + " not found"}
+}
@@ -0,0 +1,581 @@
+package github
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wahlandcase/attuned.prmanager/internal/git"
+	"github.com/wahlandcase/attuned.prmanager/internal/models"
+)
+
+// DefaultAPIURL is the public GitHub REST API endpoint
+const DefaultAPIURL = "https://api.github.com"
+
+// APIClient implements Client against the GitHub REST API directly (no gh binary needed)
+type APIClient struct {
+	baseURL string
+	token   string
+	http    *http.Client
+
+	// ResolveRepo maps a local repo path to "owner/name". Defaults to parsing the origin remote.
+	ResolveRepo func(repoPath string) (string, error)
+
+	mu    sync.Mutex
+	slugs map[string]string // repoPath -> owner/name cache
+}
+
+// NewAPIClient creates a REST client for the given API base URL (empty = api.github.com)
+func NewAPIClient(baseURL, token string) *APIClient {
+	if baseURL == "" {
+		baseURL = DefaultAPIURL
+	}
+	return &APIClient{
+		baseURL:     strings.TrimRight(baseURL, "/"),
+		token:       token,
+		http:        &http.Client{Timeout: 30 * time.Second},
+		ResolveRepo: OriginRepo,
+		slugs:       make(map[string]string),
+	}
+}
+
+// ResolveToken finds a token for the GitHub instance at apiURL (empty = github.com) from
+// GITHUB_TOKEN, GH_TOKEN, GH_ENTERPRISE_TOKEN or GITHUB_ENTERPRISE_TOKEN (GitHub Enterprise only),
+// or that host's entry in the gh CLI hosts.yml
+func ResolveToken(apiURL string) string {
+	host := apiHost(apiURL)
+	envs := []string{"GITHUB_TOKEN", "GH_TOKEN"}
+	if host != "github.com" {
+		envs = append(envs, "GH_ENTERPRISE_TOKEN", "GITHUB_ENTERPRISE_TOKEN")
+	}
+	for _, env := range envs {
+		if token := strings.TrimSpace(os.Getenv(env)); token != "" {
+			return token
+		}
+	}
+	return ghHostsToken(host)
+}
+
+// apiHost returns the GitHub host an API base URL belongs to, as gh names it in hosts.yml
+func apiHost(apiURL string) string {
+	if apiURL == "" {
+		return "github.com"
+	}
+	u, err := url.Parse(apiURL)
+	if err != nil || u.Hostname() == "" {
+		return "github.com"
+	}
+	// api.github.com serves github.com; GitHub Enterprise serves https://host/api/v3
+	host := u.Hostname()
+	if host == "api.github.com" {
+		return "github.com"
+	}
+	return host
+}
+
+// ghHostsToken reads oauth_token for host from gh's hosts.yml (empty if not found)
+func ghHostsToken(host string) string {
+	dir := os.Getenv("GH_CONFIG_DIR")
+	if dir == "" {
+		if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+			dir = filepath.Join(xdg, "gh")
+		} else if home, err := os.UserHomeDir(); err == nil {
+			dir = filepath.Join(home, ".config", "gh")
+		}
+	}
+
+	f, err := os.Open(filepath.Join(dir, "hosts.yml"))
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+
+	// Minimal YAML scan: top-level "host:" keys, indented "oauth_token: value" entries
+	inHost := false
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		if !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "\t") {
+			inHost = strings.TrimSuffix(trimmed, ":") == host
+			continue
+		}
+		if inHost && strings.HasPrefix(trimmed, "oauth_token:") {
+			value := strings.TrimSpace(strings.TrimPrefix(trimmed, "oauth_token:"))
+			return strings.Trim(value, `"'`)
+		}
+	}
+	return ""
+}
+
+// OriginRepo derives "owner/name" from the origin remote of a local repo
+func OriginRepo(repoPath string) (string, error) {
+	remoteURL, err := git.RemoteURL(repoPath, "origin")
+	if err != nil {
+		return "", err
+	}
+	slug := ParseRepoSlug(remoteURL)
+	if slug == "" {
+		return "", fmt.Errorf("cannot parse GitHub repo from origin URL %q", remoteURL)
+	}
+	return slug, nil
+}
+
+// ParseRepoSlug extracts "owner/name" from an SSH or HTTPS remote URL (empty if unrecognized)
+func ParseRepoSlug(remoteURL string) string {
+	path := remoteURL
+	if u, err := url.Parse(remoteURL); err == nil && u.Scheme != "" {
+		path = u.Path // https://host/owner/name.git, ssh://git@host/owner/name.git
+	} else if idx := strings.Index(remoteURL, ":"); idx != -1 {
+		path = remoteURL[idx+1:] // git@host:owner/name.git
+	}
+
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+	parts := strings.Split(path, "/")
+	if len(parts) < 2 || parts[len(parts)-2] == "" || parts[len(parts)-1] == "" {
+		return ""
+	}
+	return parts[len(parts)-2] + "/" + parts[len(parts)-1]
+}
+
+// repoSlug resolves and caches the owner/name for a repo path
+func (c *APIClient) repoSlug(repoPath string) (string, error) {
+	c.mu.Lock()
+	slug, ok := c.slugs[repoPath]
+	c.mu.Unlock()
+	if ok {
+		return slug, nil
+	}
+
+	slug, err := c.ResolveRepo(repoPath)
+	if err != nil {
+		return "", err
+	}
+
+	c.mu.Lock()
+	c.slugs[repoPath] = slug
+	c.mu.Unlock()
+	return slug, nil
+}
+
+// apiError is the error payload returned by the GitHub API
+type apiError struct {
+	Message string `json:"message"`
+}
+
+// do sends a request and decodes the JSON response into out (if non-nil)
+func (c *APIClient) do(method, path string, body, out any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, c.baseURL+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s %s failed: %w", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var apiErr apiError
+		if json.Unmarshal(data, &apiErr) == nil && apiErr.Message != "" {
+			return fmt.Errorf("%s %s failed (%d): %s", method, path, resp.StatusCode, apiErr.Message)
+		}
+		return fmt.Errorf("%s %s failed (%d)", method, path, resp.StatusCode)
+	}
+
+	if out == nil || len(data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("failed to parse %s %s response: %w", method, path, err)
+	}
+	return nil
+}
+
+// apiPR is the subset of the REST pull request object we use
+type apiPR struct {
+	Number  uint64 `json:"number"`
+	HTMLURL string `json:"html_url"`
+	Title   string `json:"title"`
+	State   string `json:"state"`
+	Body    string `json:"body"`
+	NodeID  string `json:"node_id"`
+	Head    struct {
+		Ref string `json:"ref"`
+	} `json:"head"`
+}
+
+func (p apiPR) toGhPr() *models.GhPr {
+	return &models.GhPr{
+		Number: p.Number,
+		URL:    p.HTMLURL,
+		Title:  p.Title,
+		State:  p.State,
+		Body:   p.Body,
+	}
+}
+
+// CheckAuth verifies the token is present and accepted by GitHub
+func (c *APIClient) CheckAuth() error {
+	if c.token == "" {
+		return fmt.Errorf("no GitHub token found. Set GITHUB_TOKEN or GH_TOKEN, or run 'gh auth login'")
+	}
+	if err := c.do(http.MethodGet, "/user", nil, nil); err != nil {
+		return fmt.Errorf("GitHub token rejected: %w", err)
+	}
+	return nil
+}
+
+// GetExistingPR gets an existing open PR for the given head -> base branch
+func (c *APIClient) GetExistingPR(repoPath, headBranch, baseBranch string) (*models.GhPr, error) {
+	slug, err := c.repoSlug(repoPath)
+	if err != nil {
+		return nil, err
+	}
+	owner := strings.Split(slug, "/")[0]
+
+	query := url.Values{}
+	query.Set("head", owner+":"+headBranch)
+	query.Set("base", baseBranch)
+	query.Set("state", "open")
+
+	var prs []apiPR
+	if err := c.do(http.MethodGet, "/repos/"+slug+"/pulls?"+query.Encode(), nil, &prs); err != nil {
+		return nil, err
+	}
+
+	if len(prs) == 0 {
+		return nil, nil
+	}
+	return prs[0].toGhPr(), nil
+}
+
+// CreatePR creates a new pull request
+func (c *APIClient) CreatePR(repoPath, headBranch, baseBranch, title, body string) (*models.GhPr, error) {
+	slug, err := c.repoSlug(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req := map[string]string{
+		"title": title,
+		"head":  headBranch,
+		"base":  baseBranch,
+		"body":  body,
+	}
+	var pr apiPR
+	if err := c.do(http.MethodPost, "/repos/"+slug+"/pulls", req, &pr); err != nil {
+		return nil, err
+	}
+	return pr.toGhPr(), nil
+}
+
+// UpdatePR updates an existing PR's title and body
+func (c *APIClient) UpdatePR(repoPath string, prNumber uint64, title, body string) (*models.GhPr, error) {
+	slug, err := c.repoSlug(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req := map[string]string{
+		"title": title,
+		"body":  body,
+	}
+	var pr apiPR
+	if err := c.do(http.MethodPatch, "/repos/"+slug+"/pulls/"+strconv.FormatUint(prNumber, 10), req, &pr); err != nil {
+		return nil, err
+	}
+	return pr.toGhPr(), nil
+}
+
+// GetPR gets PR details by number
+func (c *APIClient) GetPR(repoPath string, prNumber uint64) (*models.GhPr, error) {
+	slug, err := c.repoSlug(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var pr apiPR
+	if err := c.do(http.MethodGet, "/repos/"+slug+"/pulls/"+strconv.FormatUint(prNumber, 10), nil, &pr); err != nil {
+		return nil, err
+	}
+	return pr.toGhPr(), nil
+}
+
+// AddLabelsAndReviewers adds labels and requests reviewers on a PR
+func (c *APIClient) AddLabelsAndReviewers(repoPath string, prNumber uint64, labels, reviewers []string) error {
+	slug, err := c.repoSlug(repoPath)
+	if err != nil {
+		return err
+	}
+	number := strconv.FormatUint(prNumber, 10)
+
+	if len(labels) > 0 {
+		req := map[string][]string{"labels": labels}
+		if err := c.do(http.MethodPost, "/repos/"+slug+"/issues/"+number+"/labels", req, nil); err != nil {
+			return err
+		}
+	}
+
+	if len(reviewers) > 0 {
+		// Team reviewers are given as "org/team" but the API wants just the team slug
+		users, teams := []string{}, []string{}
+		for _, r := range reviewers {
+			if _, team, ok := strings.Cut(r, "/"); ok {
+				teams = append(teams, team)
+			} else {
+				users = append(users, r)
+			}
+		}
+		req := map[string][]string{"reviewers": users, "team_reviewers": teams}
+		if err := c.do(http.MethodPost, "/repos/"+slug+"/pulls/"+number+"/requested_reviewers", req, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MergePR merges a PR with the given strategy (merge, squash or rebase)
+func (c *APIClient) MergePR(repoPath string, prNumber uint64, opts models.MergeOptions) error {
+	slug, err := c.repoSlug(repoPath)
+	if err != nil {
+		return err
+	}
+	prPath := "/repos/" + slug + "/pulls/" + strconv.FormatUint(prNumber, 10)
+
+	// Look up the head branch before it's merged so it can be deleted afterwards
+	var pr apiPR
+	if opts.DeleteBranch {
+		if err := c.do(http.MethodGet, prPath, nil, &pr); err != nil {
+			return err
+		}
+	}
+
+	req := map[string]string{"merge_method": string(opts.Strategy)}
+	if opts.Strategy != models.MergeStrategyRebase {
+		if opts.Subject != "" {
+			req["commit_title"] = opts.Subject
+		}
+		if opts.Body != "" {
+			req["commit_message"] = opts.Body
+		}
+	}
+	if err := c.do(http.MethodPut, prPath+"/merge", req, nil); err != nil {
+		return err
+	}
+
+	if opts.DeleteBranch && pr.Head.Ref != "" {
+		if err := c.do(http.MethodDelete, "/repos/"+slug+"/git/refs/heads/"+pr.Head.Ref, nil, nil); err != nil {
+			return fmt.Errorf("merged, but failed to delete branch %s: %w", pr.Head.Ref, err)
+		}
+	}
+	return nil
+}
+
+// graphqlURL returns the GraphQL endpoint matching the REST base URL
+func (c *APIClient) graphqlURL() string {
+	// GitHub Enterprise: https://host/api/v3 -> https://host/api/graphql
+	if base, ok := strings.CutSuffix(c.baseURL, "/api/v3"); ok {
+		return base + "/api/graphql"
+	}
+	return c.baseURL + "/graphql"
+}
+
+// graphql posts a GraphQL request and returns the raw response body
+func (c *APIClient) graphql(payload any) ([]byte, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, c.graphqlURL(), bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("POST graphql failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("POST graphql failed (%d)", resp.StatusCode)
+	}
+	return body, nil
+}
+
+// ListOpenReleasePRs gets open release PRs for all repos via batched GraphQL queries
+func (c *APIClient) ListOpenReleasePRs(repos []models.RepoInfo) (map[string]models.RepoPrStatus, error) {
+	return listOpenReleasePRsGraphQL(repos, c.repoSlug, func(query string) ([]byte, error) {
+		return c.graphql(map[string]string{"query": query})
+	})
+}
+
+// EnableAutoMerge turns on GitHub auto-merge so the PR merges once its requirements pass
+func (c *APIClient) EnableAutoMerge(repoPath string, prNumber uint64, opts models.MergeOptions) error {
+	slug, err := c.repoSlug(repoPath)
+	if err != nil {
+		return err
+	}
+
+	// The mutation needs the PR's node ID
+	var pr apiPR
+	if err := c.do(http.MethodGet, "/repos/"+slug+"/pulls/"+strconv.FormatUint(prNumber, 10), nil, &pr); err != nil {
+		return err
+	}
+
+	input := map[string]any{
+		"pullRequestId": pr.NodeID,
+		"mergeMethod":   strings.ToUpper(string(opts.Strategy)),
+	}
+	if opts.Strategy != models.MergeStrategyRebase {
+		if opts.Subject != "" {
+			input["commitHeadline"] = opts.Subject
+		}
+		if opts.Body != "" {
+			input["commitBody"] = opts.Body
+		}
+	}
+	body, err := c.graphql(map[string]any{
+		"query":     enableAutoMergeMutation,
+		"variables": map[string]any{"input": input},
+	})
+	if err != nil {
+		return err
+	}
+	return graphqlError(body)
+}
+
+// apiWorkflowRun is the REST workflow run object
+type apiWorkflowRun struct {
+	ID           uint64    `json:"id"`
+	DisplayTitle string    `json:"display_title"`
+	Name         string    `json:"name"`
+	Status       string    `json:"status"`
+	Conclusion   string    `json:"conclusion"`
+	HeadBranch   string    `json:"head_branch"`
+	HeadSHA      string    `json:"head_sha"`
+	Event        string    `json:"event"`
+	HTMLURL      string    `json:"html_url"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// ListWorkflowRuns lists recent workflow runs for a repo
+func (c *APIClient) ListWorkflowRuns(repoPath string, limit int) ([]models.WorkflowRun, error) {
+	slug, err := c.repoSlug(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		WorkflowRuns []apiWorkflowRun `json:"workflow_runs"`
+	}
+	if err := c.do(http.MethodGet, "/repos/"+slug+"/actions/runs?per_page="+strconv.Itoa(limit), nil, &result); err != nil {
+		return nil, err
+	}
+
+	runs := make([]models.WorkflowRun, 0, len(result.WorkflowRuns))
+	for _, r := range result.WorkflowRuns {
+		runs = append(runs, models.WorkflowRun{
+			DatabaseID:   r.ID,
+			DisplayTitle: r.DisplayTitle,
+			WorkflowName: r.Name,
+			Status:       r.Status,
+			Conclusion:   r.Conclusion,
+			HeadBranch:   r.HeadBranch,
+			HeadSHA:      r.HeadSHA,
+			Event:        r.Event,
+			URL:          r.HTMLURL,
+			CreatedAt:    r.CreatedAt,
+			UpdatedAt:    r.UpdatedAt,
+		})
+	}
+	return runs, nil
+}
+
+// apiWorkflowJob is the REST workflow job object
+type apiWorkflowJob struct {
+	Name        string                `json:"name"`
+	Status      string                `json:"status"`
+	Conclusion  string                `json:"conclusion"`
+	StartedAt   time.Time             `json:"started_at"`
+	CompletedAt time.Time             `json:"completed_at"`
+	HTMLURL     string                `json:"html_url"`
+	Steps       []models.WorkflowStep `json:"steps"`
+}
+
+// GetWorkflowRunJobs gets the jobs for a specific workflow run
+func (c *APIClient) GetWorkflowRunJobs(repoPath string, runID uint64) ([]models.WorkflowJob, error) {
+	slug, err := c.repoSlug(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Jobs []apiWorkflowJob `json:"jobs"`
+	}
+	if err := c.do(http.MethodGet, "/repos/"+slug+"/actions/runs/"+strconv.FormatUint(runID, 10)+"/jobs", nil, &result); err != nil {
+		return nil, err
+	}
+
+	jobs := make([]models.WorkflowJob, 0, len(result.Jobs))
+	for _, j := range result.Jobs {
+		jobs = append(jobs, models.WorkflowJob{
+			Name:        j.Name,
+			Status:      j.Status,
+			Conclusion:  j.Conclusion,
+			StartedAt:   j.StartedAt,
+			CompletedAt: j.CompletedAt,
+			Steps:       j.Steps,
+			URL:         j.HTMLURL,
+		})
+	}
+	return jobs, nil
+}
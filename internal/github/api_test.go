@@ -0,0 +1,247 @@
+package github
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/wahlandcase/attuned.prmanager/internal/models"
+)
+
+// apiRequest is a request recorded by the test server
+type apiRequest struct {
+	Method string
+	Path   string
+	Query  string
+	Auth   string
+	Body   map[string]any
+}
+
+// apiRoute answers one "METHOD /path" with a status and JSON body
+type apiRoute struct {
+	status int
+	body   string
+}
+
+// newTestAPIClient starts a server answering the given routes and returns a client for the
+// "acme/web" repo along with the requests it received
+func newTestAPIClient(t *testing.T, routes map[string]apiRoute) (*APIClient, *[]apiRequest) {
+	t.Helper()
+	var (
+		mu       sync.Mutex
+		requests []apiRequest
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := apiRequest{Method: r.Method, Path: r.URL.Path, Query: r.URL.RawQuery, Auth: r.Header.Get("Authorization")}
+		if data, _ := io.ReadAll(r.Body); len(data) > 0 {
+			if err := json.Unmarshal(data, &req.Body); err != nil {
+				t.Errorf("request body isn't JSON: %s", data)
+			}
+		}
+		mu.Lock()
+		requests = append(requests, req)
+		mu.Unlock()
+
+		route, ok := routes[r.Method+" "+r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, `{"message":"Not Found"}`)
+			return
+		}
+		w.WriteHeader(route.status)
+		io.WriteString(w, route.body)
+	}))
+	t.Cleanup(srv.Close)
+
+	c := NewAPIClient(srv.URL, "secret")
+	c.ResolveRepo = func(string) (string, error) { return "acme/web", nil }
+	return c, &requests
+}
+
+func TestAPIClientGetExistingPR(t *testing.T) {
+	c, requests := newTestAPIClient(t, map[string]apiRoute{
+		"GET /repos/acme/web/pulls": {http.StatusOK, `[{"number":7,"html_url":"https://github.com/acme/web/pull/7","title":"dev → staging","state":"open"}]`},
+	})
+
+	pr, err := c.GetExistingPR("/repos/web", "dev", "staging")
+	if err != nil {
+		t.Fatalf("GetExistingPR: %v", err)
+	}
+	if pr == nil || pr.Number != 7 || pr.URL != "https://github.com/acme/web/pull/7" || pr.State != "open" {
+		t.Errorf("GetExistingPR = %+v", pr)
+	}
+
+	req := (*requests)[0]
+	if req.Query != "base=staging&head=acme%3Adev&state=open" {
+		t.Errorf("query = %q, want head qualified with the owner", req.Query)
+	}
+	if req.Auth != "Bearer secret" {
+		t.Errorf("Authorization = %q", req.Auth)
+	}
+}
+
+func TestAPIClientGetExistingPRNone(t *testing.T) {
+	c, _ := newTestAPIClient(t, map[string]apiRoute{
+		"GET /repos/acme/web/pulls": {http.StatusOK, `[]`},
+	})
+
+	pr, err := c.GetExistingPR("/repos/web", "dev", "staging")
+	if err != nil || pr != nil {
+		t.Errorf("GetExistingPR = %+v, %v; want nil, nil", pr, err)
+	}
+}
+
+func TestAPIClientCreateAndUpdatePR(t *testing.T) {
+	c, requests := newTestAPIClient(t, map[string]apiRoute{
+		"POST /repos/acme/web/pulls":    {http.StatusCreated, `{"number":8,"html_url":"https://github.com/acme/web/pull/8","title":"Release","state":"open"}`},
+		"PATCH /repos/acme/web/pulls/8": {http.StatusOK, `{"number":8,"html_url":"https://github.com/acme/web/pull/8","title":"Release 2","state":"open","body":"new"}`},
+	})
+
+	pr, err := c.CreatePR("/repos/web", "dev", "staging", "Release", "body")
+	if err != nil {
+		t.Fatalf("CreatePR: %v", err)
+	}
+	if pr.Number != 8 || pr.Title != "Release" {
+		t.Errorf("CreatePR = %+v", pr)
+	}
+
+	pr, err = c.UpdatePR("/repos/web", 8, "Release 2", "new")
+	if err != nil {
+		t.Fatalf("UpdatePR: %v", err)
+	}
+	if pr.Title != "Release 2" || pr.Body != "new" {
+		t.Errorf("UpdatePR = %+v", pr)
+	}
+
+	create, update := (*requests)[0].Body, (*requests)[1].Body
+	if create["head"] != "dev" || create["base"] != "staging" || create["title"] != "Release" || create["body"] != "body" {
+		t.Errorf("create request = %v", create)
+	}
+	if update["title"] != "Release 2" || update["body"] != "new" {
+		t.Errorf("update request = %v", update)
+	}
+}
+
+func TestAPIClientMergePR(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     models.MergeOptions
+		requests []string
+		merge    map[string]any
+	}{
+		{
+			name:     "squash with message",
+			opts:     models.MergeOptions{Strategy: models.MergeStrategySquash, Subject: "Release", Body: "Details"},
+			requests: []string{"PUT /repos/acme/web/pulls/9/merge"},
+			merge:    map[string]any{"merge_method": "squash", "commit_title": "Release", "commit_message": "Details"},
+		},
+		{
+			name:     "rebase ignores message",
+			opts:     models.MergeOptions{Strategy: models.MergeStrategyRebase, Subject: "Release"},
+			requests: []string{"PUT /repos/acme/web/pulls/9/merge"},
+			merge:    map[string]any{"merge_method": "rebase"},
+		},
+		{
+			name: "delete branch after merging",
+			opts: models.MergeOptions{Strategy: models.MergeStrategyMerge, DeleteBranch: true},
+			requests: []string{
+				"GET /repos/acme/web/pulls/9",
+				"PUT /repos/acme/web/pulls/9/merge",
+				"DELETE /repos/acme/web/git/refs/heads/feature",
+			},
+			merge: map[string]any{"merge_method": "merge"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, requests := newTestAPIClient(t, map[string]apiRoute{
+				"GET /repos/acme/web/pulls/9":                   {http.StatusOK, `{"number":9,"head":{"ref":"feature"}}`},
+				"PUT /repos/acme/web/pulls/9/merge":             {http.StatusOK, `{"merged":true}`},
+				"DELETE /repos/acme/web/git/refs/heads/feature": {http.StatusNoContent, ``},
+			})
+
+			if err := c.MergePR("/repos/web", 9, tt.opts); err != nil {
+				t.Fatalf("MergePR: %v", err)
+			}
+
+			var got []string
+			var merge map[string]any
+			for _, r := range *requests {
+				got = append(got, r.Method+" "+r.Path)
+				if r.Method == http.MethodPut {
+					merge = r.Body
+				}
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.requests, "\n") {
+				t.Errorf("requests = %v, want %v", got, tt.requests)
+			}
+			if len(merge) != len(tt.merge) {
+				t.Errorf("merge request = %v, want %v", merge, tt.merge)
+			}
+			for k, v := range tt.merge {
+				if merge[k] != v {
+					t.Errorf("merge request %s = %v, want %v", k, merge[k], v)
+				}
+			}
+		})
+	}
+}
+
+func TestAPIClientErrorPayload(t *testing.T) {
+	c, _ := newTestAPIClient(t, map[string]apiRoute{
+		"POST /repos/acme/web/pulls":        {http.StatusUnprocessableEntity, `{"message":"Validation Failed","errors":[{"code":"custom"}]}`},
+		"PUT /repos/acme/web/pulls/9/merge": {http.StatusMethodNotAllowed, `not json`},
+	})
+
+	_, err := c.CreatePR("/repos/web", "dev", "staging", "Release", "")
+	if err == nil || !strings.Contains(err.Error(), "(422): Validation Failed") {
+		t.Errorf("CreatePR error = %v, want the GitHub message", err)
+	}
+
+	err = c.MergePR("/repos/web", 9, models.MergeOptions{Strategy: models.MergeStrategyMerge})
+	if err == nil || !strings.HasSuffix(err.Error(), "failed (405)") {
+		t.Errorf("MergePR error = %v, want the status code", err)
+	}
+}
+
+func TestResolveToken(t *testing.T) {
+	dir := t.TempDir()
+	hosts := "github.com:\n    oauth_token: gho_public\n    user: dev\nghe.example.com:\n    oauth_token: \"gho_enterprise\"\n"
+	if err := os.WriteFile(filepath.Join(dir, "hosts.yml"), []byte(hosts), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("GH_CONFIG_DIR", dir)
+	for _, env := range []string{"GITHUB_TOKEN", "GH_TOKEN", "GH_ENTERPRISE_TOKEN", "GITHUB_ENTERPRISE_TOKEN"} {
+		t.Setenv(env, "")
+	}
+
+	tests := []struct {
+		apiURL string
+		want   string
+	}{
+		{"", "gho_public"},
+		{"https://api.github.com", "gho_public"},
+		{"https://ghe.example.com/api/v3", "gho_enterprise"},
+		{"https://other.example.com/api/v3", ""},
+	}
+	for _, tt := range tests {
+		if got := ResolveToken(tt.apiURL); got != tt.want {
+			t.Errorf("ResolveToken(%q) = %q, want %q", tt.apiURL, got, tt.want)
+		}
+	}
+
+	t.Setenv("GH_ENTERPRISE_TOKEN", "env_enterprise")
+	if got := ResolveToken("https://ghe.example.com/api/v3"); got != "env_enterprise" {
+		t.Errorf("ResolveToken with GH_ENTERPRISE_TOKEN = %q", got)
+	}
+	if got := ResolveToken(""); got != "gho_public" {
+		t.Errorf("ResolveToken for github.com used the enterprise token: %q", got)
+	}
+}
@@ -0,0 +1,72 @@
+package github
+
+import (
+	"slices"
+	"strings"
+)
+
+// Markers around the part of a PR body attpr manages. Updates only replace what's between
+// them, so notes added to the description by hand survive.
+const (
+	BodyStartMarker = "<!-- attpr:start -->"
+	BodyEndMarker   = "<!-- attpr:end -->"
+)
+
+// Headings of the blocks attpr generated before it used markers
+const (
+	legacyTicketsHeading   = "# Tickets"
+	legacyChangelogHeading = "# Changelog"
+)
+
+// legacyChangelogSections are the headings a legacy changelog block can contain
+var legacyChangelogSections = []string{"## Breaking changes", "## Features", "## Fixes", "## Other"}
+
+// MergeBody puts generated content into attpr's region of an existing PR body. Text outside
+// the markers is kept as is. A body attpr generated before markers were used has its tickets
+// and changelog blocks replaced, and whatever follows them is kept below the new region.
+// Any other body without both markers in order (one whose markers were edited out) keeps
+// its text, minus stray markers, below a new region rather than losing it.
+func MergeBody(existing, generated string) string {
+	region := BodyStartMarker + "\n" + generated + "\n" + BodyEndMarker
+
+	start := strings.Index(existing, BodyStartMarker)
+	end := strings.Index(existing, BodyEndMarker)
+	if start >= 0 && end > start {
+		return existing[:start] + region + existing[end+len(BodyEndMarker):]
+	}
+
+	kept := strings.NewReplacer(BodyStartMarker, "", BodyEndMarker, "").Replace(existing)
+	kept = strings.TrimSpace(stripLegacyBody(strings.TrimSpace(kept)))
+	if kept == "" {
+		return region
+	}
+	return region + "\n\n" + kept
+}
+
+// stripLegacyBody removes the tickets and changelog blocks from the start of a body attpr
+// generated before it used markers, returning the rest. Other bodies are returned as is.
+func stripLegacyBody(body string) string {
+	lines := strings.Split(body, "\n")
+	i := 0
+	// skip advances past blank lines and lines matching generated
+	skip := func(generated func(string) bool) {
+		for i < len(lines) && (strings.TrimSpace(lines[i]) == "" || generated(lines[i])) {
+			i++
+		}
+	}
+	if lines[i] == legacyTicketsHeading {
+		i++
+		skip(func(line string) bool { return strings.HasPrefix(line, "### - ") })
+	}
+	if i < len(lines) && lines[i] == legacyChangelogHeading {
+		i++
+		// Section headings and "- <change> (<hash>)" entries
+		skip(func(line string) bool {
+			return slices.Contains(legacyChangelogSections, line) || strings.HasPrefix(line, "- ") && strings.HasSuffix(line, ")")
+		})
+	}
+	if i == 0 {
+		return body
+	}
+	return strings.Join(lines[i:], "\n")
+}
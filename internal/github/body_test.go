@@ -0,0 +1,93 @@
+package github
+
+import "testing"
+
+func TestMergeBody(t *testing.T) {
+	const generated = "# Tickets\n\n### - ATT-2"
+	region := BodyStartMarker + "\n" + generated + "\n" + BodyEndMarker
+
+	tests := []struct {
+		name     string
+		existing string
+		want     string
+	}{
+		{
+			name:     "empty body",
+			existing: "",
+			want:     region,
+		},
+		{
+			name:     "whitespace body",
+			existing: "\n  \n",
+			want:     region,
+		},
+		{
+			name:     "replaces region and keeps manual edits",
+			existing: "Intro\n\n" + BodyStartMarker + "\nold\n" + BodyEndMarker + "\n\nQA notes",
+			want:     "Intro\n\n" + region + "\n\nQA notes",
+		},
+		{
+			name:     "missing markers keeps text below",
+			existing: "Written by hand",
+			want:     region + "\n\nWritten by hand",
+		},
+		{
+			name:     "legacy tickets body is replaced",
+			existing: "# Tickets\n\n### - [ATT-1](https://linear.app/attuned/issue/ATT-1)",
+			want:     region,
+		},
+		{
+			name:     "legacy changelog body is replaced",
+			existing: "# Changelog\n\n## Features\n\n- login (abc1234)\n",
+			want:     region,
+		},
+		{
+			name:     "legacy tickets and changelog body is replaced",
+			existing: "# Tickets\n\n### - Closes [ATT-1](https://linear.app/attuned/issue/att-1)\n\n# Changelog\n\n## Fixes\n\n- login typo (abc1234)",
+			want:     region,
+		},
+		{
+			name:     "QA notes after a legacy tickets block are kept",
+			existing: "# Tickets\n\n### - Closes [ATT-1](https://linear.app/attuned/issue/att-1)\n### - Closes [ATT-3](https://linear.app/attuned/issue/att-3)\n\n## QA notes\n\n- Checked login on Safari\n- ATT-3 needs a migration first",
+			want:     region + "\n\n## QA notes\n\n- Checked login on Safari\n- ATT-3 needs a migration first",
+		},
+		{
+			name:     "QA notes after a legacy changelog are kept",
+			existing: "# Tickets\n\n### - Closes [ATT-1](https://linear.app/attuned/issue/att-1)\n\n# Changelog\n\n## Features\n\n- login (abc1234)\n\n## QA\n\n- Signed off by Sam (staging)",
+			want:     region + "\n\n## QA\n\n- Signed off by Sam (staging)",
+		},
+		{
+			name:     "heading that only starts like a legacy one is kept",
+			existing: "# Ticketsystem migration",
+			want:     region + "\n\n# Ticketsystem migration",
+		},
+		{
+			name:     "reversed markers",
+			existing: "Before\n" + BodyEndMarker + "\nmiddle\n" + BodyStartMarker + "\nAfter",
+			want:     region + "\n\nBefore\n\nmiddle\n\nAfter",
+		},
+		{
+			name:     "only start marker",
+			existing: "Notes\n" + BodyStartMarker + "\nold generated",
+			want:     region + "\n\nNotes\n\nold generated",
+		},
+		{
+			name:     "only end marker",
+			existing: "old generated\n" + BodyEndMarker + "\nNotes",
+			want:     region + "\n\nold generated\n\nNotes",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := MergeBody(tt.existing, generated)
+			if got != tt.want {
+				t.Errorf("MergeBody() =\n%q\nwant\n%q", got, tt.want)
+			}
+			// Merging again is stable
+			if again := MergeBody(got, generated); again != got {
+				t.Errorf("second MergeBody() =\n%q\nwant\n%q", again, got)
+			}
+		})
+	}
+}
@@ -1,230 +1,187 @@
 package github
 
 import (
-	"encoding/json"
 	"fmt"
-	"os/exec"
-	"strconv"
+	"slices"
+	"sort"
 	"strings"
+	"time"
 
+	"github.com/wahlandcase/attuned.prmanager/internal/git"
 	"github.com/wahlandcase/attuned.prmanager/internal/models"
 )
 
-// CheckAuth verifies gh CLI is authenticated
-func CheckAuth() error {
-	cmd := exec.Command("gh", "auth", "status")
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("not authenticated with GitHub CLI. Run 'gh auth login' first")
-	}
-	return nil
-}
-
-// GetExistingPR gets an existing open PR for the given head -> base branch
-func GetExistingPR(repoPath, headBranch, baseBranch string) (*models.GhPr, error) {
-	cmd := exec.Command("gh", "pr", "list",
-		"--head", headBranch,
-		"--base", baseBranch,
-		"--state", "open",
-		"--json", "number,url,title,state",
-	)
-	cmd.Dir = repoPath
-
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return nil, fmt.Errorf("gh pr list failed: %s", string(output))
-	}
-
-	var prs []models.GhPr
-	if err := json.Unmarshal(output, &prs); err != nil {
-		return nil, fmt.Errorf("failed to parse gh pr list output: %w", err)
-	}
-
-	if len(prs) == 0 {
-		return nil, nil
-	}
-
-	return &prs[0], nil
-}
-
-// CreatePR creates a new pull request
-func CreatePR(repoPath, headBranch, baseBranch, title, body string) (*models.GhPr, error) {
-	cmd := exec.Command("gh", "pr", "create",
-		"--head", headBranch,
-		"--base", baseBranch,
-		"--title", title,
-		"--body", body,
-	)
-	cmd.Dir = repoPath
-
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return nil, fmt.Errorf("gh pr create failed: %s", string(output))
-	}
-
-	// gh pr create outputs the URL
-	url := strings.TrimSpace(string(output))
-
-	// Extract PR number from URL (e.g., https://github.com/org/repo/pull/123)
-	parts := strings.Split(url, "/")
-	var number uint64
-	if len(parts) > 0 {
-		number, _ = strconv.ParseUint(parts[len(parts)-1], 10, 64)
-	}
-
-	return &models.GhPr{
-		Number: number,
-		URL:    url,
-		Title:  title,
-		State:  "open",
-	}, nil
-}
-
-// UpdatePR updates an existing PR's title and body
-func UpdatePR(repoPath string, prNumber uint64, title, body string) (*models.GhPr, error) {
-	cmd := exec.Command("gh", "pr", "edit",
-		strconv.FormatUint(prNumber, 10),
-		"--title", title,
-		"--body", body,
-	)
-	cmd.Dir = repoPath
-
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return nil, fmt.Errorf("gh pr edit failed: %s", string(output))
-	}
-
-	// Get the updated PR info
-	return GetPR(repoPath, prNumber)
-}
-
-// GetPR gets PR details by number
-func GetPR(repoPath string, prNumber uint64) (*models.GhPr, error) {
-	cmd := exec.Command("gh", "pr", "view",
-		strconv.FormatUint(prNumber, 10),
-		"--json", "number,url,title,state",
-	)
-	cmd.Dir = repoPath
-
-	output, err := cmd.Output()
-	if err != nil {
-		return nil, fmt.Errorf("gh pr view failed: %w", err)
-	}
-
-	var pr models.GhPr
-	if err := json.Unmarshal(output, &pr); err != nil {
-		return nil, fmt.Errorf("failed to parse gh pr view output: %w", err)
-	}
-
-	return &pr, nil
+// Client is the set of GitHub operations attpr needs. All methods take the
+// local repository path; implementations resolve the GitHub repo from it.
+type Client interface {
+	// CheckAuth verifies the client can talk to GitHub
+	CheckAuth() error
+	// GetExistingPR gets an existing open PR for the given head -> base branch (nil if none)
+	GetExistingPR(repoPath, headBranch, baseBranch string) (*models.GhPr, error)
+	// CreatePR creates a new pull request
+	CreatePR(repoPath, headBranch, baseBranch, title, body string) (*models.GhPr, error)
+	// UpdatePR updates an existing PR's title and body
+	UpdatePR(repoPath string, prNumber uint64, title, body string) (*models.GhPr, error)
+	// GetPR gets PR details by number
+	GetPR(repoPath string, prNumber uint64) (*models.GhPr, error)
+	// AddLabelsAndReviewers adds labels and requests reviewers ("org/team" for teams) on a PR
+	AddLabelsAndReviewers(repoPath string, prNumber uint64, labels, reviewers []string) error
+	// MergePR merges a PR with the given strategy and commit message
+	MergePR(repoPath string, prNumber uint64, opts models.MergeOptions) error
+	// EnableAutoMerge has GitHub merge the PR with the given strategy once its checks and reviews pass.
+	// opts.DeleteBranch is ignored; the repo's "automatically delete head branches" setting applies.
+	EnableAutoMerge(repoPath string, prNumber uint64, opts models.MergeOptions) error
+	// ListWorkflowRuns lists recent workflow runs for a repo
+	ListWorkflowRuns(repoPath string, limit int) ([]models.WorkflowRun, error)
+	// GetWorkflowRunJobs gets the jobs for a specific workflow run
+	GetWorkflowRunJobs(repoPath string, runID uint64) ([]models.WorkflowJob, error)
+	// ListOpenReleasePRs gets open release PRs for many repos in as few requests as possible,
+	// keyed by repo path. Repos that can't be queried are omitted.
+	ListOpenReleasePRs(repos []models.RepoInfo) (map[string]models.RepoPrStatus, error)
 }
 
-// GetOpenReleasePRs gets open release PRs for a repo (dev->staging and staging->main)
-func GetOpenReleasePRs(repoPath, mainBranch string) (*models.RepoPrStatus, error) {
-	devToStaging, err := GetExistingPR(repoPath, "dev", "staging")
-	if err != nil {
-		return nil, fmt.Errorf("checking dev->staging: %w", err)
-	}
-
-	stagingToMain, err := GetExistingPR(repoPath, "staging", mainBranch)
-	if err != nil {
-		return nil, fmt.Errorf("checking staging->%s: %w", mainBranch, err)
+// GetOpenReleasePRs gets open release PRs for every promotion in a repo's flow
+func GetOpenReleasePRs(c Client, repo models.RepoInfo) (*models.RepoPrStatus, error) {
+	status := &models.RepoPrStatus{}
+	for _, prType := range repo.Flow.PrTypes() {
+		head, base := prType.HeadBranch(repo.MainBranch), prType.BaseBranch(repo.MainBranch)
+		pr, err := c.GetExistingPR(repo.Path, head, base)
+		if err != nil {
+			return nil, fmt.Errorf("checking %s->%s: %w", head, base, err)
+		}
+		status.PRs = append(status.PRs, models.ReleasePR{PrType: prType, PR: pr})
 	}
-
-	return &models.RepoPrStatus{
-		DevToStaging:  devToStaging,
-		StagingToMain: stagingToMain,
-	}, nil
+	return status, nil
 }
 
-// GeneratePRBody generates PR body with ticket links using Linear magic words
-func GeneratePRBody(tickets []string, linearOrg string) string {
+// GeneratePRBody generates PR body with ticket links and the tracker's closing keywords
+func GeneratePRBody(tickets []string, tracker models.TicketTracker) string {
 	if len(tickets) == 0 {
 		return ""
 	}
 
 	var lines []string
 	for _, t := range tickets {
-		line := fmt.Sprintf("### - Closes [%s](https://linear.app/%s/issue/%s)", t, linearOrg, strings.ToLower(t))
-		lines = append(lines, line)
+		lines = append(lines, "### - "+tracker.Reference(t))
 	}
 
 	return fmt.Sprintf("# Tickets\n\n%s", strings.Join(lines, "\n"))
 }
 
-// MergePR merges a PR using regular merge (not squash)
-func MergePR(repoPath string, prNumber uint64) error {
-	cmd := exec.Command("gh", "pr", "merge",
-		strconv.FormatUint(prNumber, 10),
-		"--merge",
-		"--delete-branch=false",
-	)
-	cmd.Dir = repoPath
+// GenerateChangelog generates a changelog grouped by conventional commit type ("" if there are no commits)
+func GenerateChangelog(commits []models.CommitInfo) string {
+	cl := models.BuildChangelog(commits)
+	if cl.IsEmpty() {
+		return ""
+	}
 
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return fmt.Errorf("gh pr merge failed: %s", string(output))
+	lines := []string{"# Changelog"}
+	// Other keeps the full subject since its type isn't implied by the heading
+	section := func(heading string, entries []models.ChangelogEntry, fullSubject bool) {
+		if len(entries) == 0 {
+			return
+		}
+		lines = append(lines, "", "## "+heading, "")
+		for _, e := range entries {
+			text := e.Commit.Message
+			if !fullSubject {
+				text = e.Description
+				if e.Scope != "" {
+					text = "**" + e.Scope + ":** " + text
+				}
+			}
+			lines = append(lines, fmt.Sprintf("- %s (%s)", text, e.Commit.Hash))
+		}
 	}
+	section("Breaking changes", cl.Breaking, false)
+	section("Features", cl.Features, false)
+	section("Fixes", cl.Fixes, false)
+	section("Other", cl.Other, true)
 
-	return nil
+	return strings.Join(lines, "\n")
 }
 
-// ListWorkflowRuns lists recent workflow runs for a repo
-func ListWorkflowRuns(repoPath string, limit int) ([]models.WorkflowRun, error) {
-	cmd := exec.Command("gh", "run", "list",
-		"--json", "databaseId,displayTitle,workflowName,status,conclusion,headBranch,event,url,createdAt,updatedAt",
-		"--limit", strconv.Itoa(limit),
-	)
-	cmd.Dir = repoPath
+// BodyOptions controls the generated parts of a PR body
+type BodyOptions struct {
+	// Tracker formats ticket links for repos without their own tracker
+	Tracker models.TicketTracker
+	// Sprint is the current sprint number for templates (0 if unknown)
+	Sprint int
+	// Changelog adds a changelog grouped by conventional commit type after the tickets
+	Changelog bool
+}
 
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return nil, fmt.Errorf("gh run list failed: %s", string(output))
+// RenderPR renders a repo's PR title and body for a promotion from its PR templates.
+// title is the entered title.
+func RenderPR(repo models.RepoInfo, prType models.PrType, title string, commits []models.CommitInfo, opts BodyOptions) (string, string, error) {
+	tickets := git.GetAllTickets(commits)
+	tracker := repo.Settings.TrackerOr(opts.Tracker)
+	data := models.PRData{
+		Title:   title,
+		Repo:    repo.DisplayName,
+		Head:    prType.HeadBranch(repo.MainBranch),
+		Base:    prType.BaseBranch(repo.MainBranch),
+		Commits: commits,
+		Date:    time.Now(),
+		Sprint:  opts.Sprint,
+	}
+	if opts.Changelog {
+		data.Changelog = GenerateChangelog(commits)
+	}
+	var sections []string
+	for _, s := range []string{GeneratePRBody(tickets, tracker), data.Changelog} {
+		if s != "" {
+			sections = append(sections, s)
+		}
 	}
-
-	var runs []models.WorkflowRun
-	if err := json.Unmarshal(output, &runs); err != nil {
-		return nil, fmt.Errorf("failed to parse gh run list output: %w", err)
+	data.DefaultBody = strings.Join(sections, "\n\n")
+	for _, t := range tickets {
+		data.Tickets = append(data.Tickets, models.TicketLink{ID: t, URL: tracker.URL(t)})
 	}
+	for _, c := range commits {
+		if c.Author != "" && !slices.Contains(data.Authors, c.Author) {
+			data.Authors = append(data.Authors, c.Author)
+		}
+	}
+	sort.Strings(data.Authors)
 
-	return runs, nil
+	return repo.Settings.PR.For(prType).Render(data)
 }
 
-// GetWorkflowRunJobs gets the jobs for a specific workflow run
-func GetWorkflowRunJobs(repoPath string, runID uint64) ([]models.WorkflowJob, error) {
-	cmd := exec.Command("gh", "run", "view",
-		strconv.FormatUint(runID, 10),
-		"--json", "jobs",
-	)
-	cmd.Dir = repoPath
-
-	output, err := cmd.CombinedOutput()
+// CreateOrUpdatePR creates a new PR or updates an existing one, then applies the repo's
+// configured labels and reviewers
+func CreateOrUpdatePR(c Client, repo models.RepoInfo, headBranch, baseBranch, title, body string) (*models.GhPr, bool, error) {
+	pr, updated, err := createOrUpdatePR(c, repo.Path, headBranch, baseBranch, title, body)
 	if err != nil {
-		return nil, fmt.Errorf("gh run view failed: %s", string(output))
+		return nil, false, err
 	}
 
-	var result struct {
-		Jobs []models.WorkflowJob `json:"jobs"`
-	}
-	if err := json.Unmarshal(output, &result); err != nil {
-		return nil, fmt.Errorf("failed to parse gh run view output: %w", err)
+	if len(repo.Settings.Labels) > 0 || len(repo.Settings.Reviewers) > 0 {
+		if err := c.AddLabelsAndReviewers(repo.Path, pr.Number, repo.Settings.Labels, repo.Settings.Reviewers); err != nil {
+			return nil, false, fmt.Errorf("PR #%d saved, but adding labels/reviewers failed: %w", pr.Number, err)
+		}
 	}
-
-	return result.Jobs, nil
+	return pr, updated, nil
 }
 
-// CreateOrUpdatePR creates a new PR or updates an existing one
-func CreateOrUpdatePR(repoPath, headBranch, baseBranch, title string, tickets []string, linearOrg string) (*models.GhPr, bool, error) {
-	body := GeneratePRBody(tickets, linearOrg)
+// createOrUpdatePR creates a new PR or updates the existing one for head -> base
+func createOrUpdatePR(c Client, repoPath, headBranch, baseBranch, title, body string) (*models.GhPr, bool, error) {
 
 	// Check for existing PR
-	existing, err := GetExistingPR(repoPath, headBranch, baseBranch)
+	existing, err := c.GetExistingPR(repoPath, headBranch, baseBranch)
 	if err != nil {
 		return nil, false, err
 	}
 
 	if existing != nil {
+		// Only replace attpr's region of the body, keeping anything added by hand
+		current, err := c.GetPR(repoPath, existing.Number)
+		if err != nil {
+			return nil, false, err
+		}
+
 		// Update existing PR
-		pr, err := UpdatePR(repoPath, existing.Number, title, body)
+		pr, err := c.UpdatePR(repoPath, existing.Number, title, MergeBody(current.Body, body))
 		if err != nil {
 			return nil, false, err
 		}
@@ -232,7 +189,7 @@ func CreateOrUpdatePR(repoPath, headBranch, baseBranch, title string, tickets []
 	}
 
 	// Create new PR
-	pr, err := CreatePR(repoPath, headBranch, baseBranch, title, body)
+	pr, err := c.CreatePR(repoPath, headBranch, baseBranch, title, MergeBody("", body))
 	if err != nil {
 		return nil, false, err
 	}
@@ -0,0 +1,298 @@
+package github
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/wahlandcase/attuned.prmanager/internal/models"
+)
+
+// FakeClient is an in-memory Client used for --dry-run and tests
+type FakeClient struct {
+	// Latency is slept at the start of every call to simulate network delay
+	Latency time.Duration
+	// AuthErr is returned by CheckAuth
+	AuthErr error
+
+	mu         sync.Mutex
+	prs        map[string][]*fakePR // repoPath -> PRs
+	runs       map[string][]models.WorkflowRun
+	jobs       map[uint64][]models.WorkflowJob
+	nextNumber uint64
+}
+
+// fakePR is a PR stored by FakeClient along with its branches and body
+type fakePR struct {
+	pr         models.GhPr
+	headBranch string
+	baseBranch string
+	body       string
+	labels     []string
+	reviewers  []string
+	mergeOpts  models.MergeOptions
+}
+
+// NewFakeClient creates an empty FakeClient
+func NewFakeClient() *FakeClient {
+	return &FakeClient{
+		prs:        make(map[string][]*fakePR),
+		runs:       make(map[string][]models.WorkflowRun),
+		jobs:       make(map[uint64][]models.WorkflowJob),
+		nextNumber: 100,
+	}
+}
+
+// AddPR seeds an open PR for the given head -> base branch
+func (f *FakeClient) AddPR(repoPath, headBranch, baseBranch string, pr models.GhPr) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if pr.State == "" {
+		pr.State = "open"
+	}
+	if pr.Number >= f.nextNumber {
+		f.nextNumber = pr.Number + 1
+	}
+	f.prs[repoPath] = append(f.prs[repoPath], &fakePR{pr: pr, headBranch: headBranch, baseBranch: baseBranch})
+}
+
+// AddWorkflowRun seeds a workflow run for a repo
+func (f *FakeClient) AddWorkflowRun(repoPath string, run models.WorkflowRun) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.runs[repoPath] = append(f.runs[repoPath], run)
+}
+
+// SetWorkflowJobs seeds the jobs returned for a workflow run
+func (f *FakeClient) SetWorkflowJobs(runID uint64, jobs []models.WorkflowJob) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.jobs[runID] = jobs
+}
+
+// PRBody returns the stored body of a PR (empty if unknown)
+func (f *FakeClient) PRBody(repoPath string, prNumber uint64) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if p := f.findPR(repoPath, prNumber); p != nil {
+		return p.body
+	}
+	return ""
+}
+
+func (f *FakeClient) sleep() {
+	if f.Latency > 0 {
+		time.Sleep(f.Latency)
+	}
+}
+
+// findPR looks up a PR by number (caller must hold mu)
+func (f *FakeClient) findPR(repoPath string, prNumber uint64) *fakePR {
+	for _, p := range f.prs[repoPath] {
+		if p.pr.Number == prNumber {
+			return p
+		}
+	}
+	return nil
+}
+
+// CheckAuth returns AuthErr
+func (f *FakeClient) CheckAuth() error {
+	return f.AuthErr
+}
+
+// GetExistingPR gets an existing open PR for the given head -> base branch
+func (f *FakeClient) GetExistingPR(repoPath, headBranch, baseBranch string) (*models.GhPr, error) {
+	f.sleep()
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, p := range f.prs[repoPath] {
+		if p.headBranch == headBranch && p.baseBranch == baseBranch && p.pr.State == "open" {
+			pr := p.pr
+			return &pr, nil
+		}
+	}
+	return nil, nil
+}
+
+// CreatePR creates a new PR in memory
+func (f *FakeClient) CreatePR(repoPath, headBranch, baseBranch, title, body string) (*models.GhPr, error) {
+	f.sleep()
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	number := f.nextNumber
+	f.nextNumber++
+	p := &fakePR{
+		pr: models.GhPr{
+			Number: number,
+			URL:    fmt.Sprintf("https://github.com/example/%s/pull/%d", filepath.Base(repoPath), number),
+			Title:  title,
+			State:  "open",
+		},
+		headBranch: headBranch,
+		baseBranch: baseBranch,
+		body:       body,
+	}
+	f.prs[repoPath] = append(f.prs[repoPath], p)
+	pr := p.pr
+	return &pr, nil
+}
+
+// UpdatePR updates a stored PR's title and body
+func (f *FakeClient) UpdatePR(repoPath string, prNumber uint64, title, body string) (*models.GhPr, error) {
+	f.sleep()
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	p := f.findPR(repoPath, prNumber)
+	if p == nil {
+		return nil, fmt.Errorf("PR #%d not found", prNumber)
+	}
+	p.pr.Title = title
+	p.body = body
+	pr := p.pr
+	return &pr, nil
+}
+
+// GetPR gets a stored PR by number
+func (f *FakeClient) GetPR(repoPath string, prNumber uint64) (*models.GhPr, error) {
+	f.sleep()
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	p := f.findPR(repoPath, prNumber)
+	if p == nil {
+		return nil, fmt.Errorf("PR #%d not found", prNumber)
+	}
+	pr := p.pr
+	pr.Body = p.body
+	return &pr, nil
+}
+
+// AddLabelsAndReviewers records labels and reviewers on a stored PR
+func (f *FakeClient) AddLabelsAndReviewers(repoPath string, prNumber uint64, labels, reviewers []string) error {
+	f.sleep()
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	p := f.findPR(repoPath, prNumber)
+	if p == nil {
+		return fmt.Errorf("PR #%d not found", prNumber)
+	}
+	p.labels = appendMissing(p.labels, labels)
+	p.reviewers = appendMissing(p.reviewers, reviewers)
+	return nil
+}
+
+// PRLabelsAndReviewers returns the labels and reviewers recorded on a PR
+func (f *FakeClient) PRLabelsAndReviewers(repoPath string, prNumber uint64) (labels, reviewers []string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if p := f.findPR(repoPath, prNumber); p != nil {
+		return append([]string(nil), p.labels...), append([]string(nil), p.reviewers...)
+	}
+	return nil, nil
+}
+
+// appendMissing appends the values not already in list
+func appendMissing(list, values []string) []string {
+	for _, v := range values {
+		if !slices.Contains(list, v) {
+			list = append(list, v)
+		}
+	}
+	return list
+}
+
+// MergePR marks a stored PR as merged, recording the options used
+func (f *FakeClient) MergePR(repoPath string, prNumber uint64, opts models.MergeOptions) error {
+	f.sleep()
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	p := f.findPR(repoPath, prNumber)
+	if p == nil {
+		return fmt.Errorf("PR #%d not found", prNumber)
+	}
+	if p.pr.State != "open" {
+		return fmt.Errorf("PR #%d is %s", prNumber, p.pr.State)
+	}
+	p.pr.State = "merged"
+	p.mergeOpts = opts
+	return nil
+}
+
+// EnableAutoMerge marks a stored PR as set to auto-merge, recording the options used
+func (f *FakeClient) EnableAutoMerge(repoPath string, prNumber uint64, opts models.MergeOptions) error {
+	f.sleep()
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	p := f.findPR(repoPath, prNumber)
+	if p == nil {
+		return fmt.Errorf("PR #%d not found", prNumber)
+	}
+	if p.pr.State != "open" {
+		return fmt.Errorf("PR #%d is %s", prNumber, p.pr.State)
+	}
+	p.pr.AutoMerge = true
+	p.mergeOpts = opts
+	return nil
+}
+
+// PRMergeOptions returns the options a PR was merged with
+func (f *FakeClient) PRMergeOptions(repoPath string, prNumber uint64) models.MergeOptions {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if p := f.findPR(repoPath, prNumber); p != nil {
+		return p.mergeOpts
+	}
+	return models.MergeOptions{}
+}
+
+// ListWorkflowRuns lists seeded workflow runs for a repo
+func (f *FakeClient) ListWorkflowRuns(repoPath string, limit int) ([]models.WorkflowRun, error) {
+	f.sleep()
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	runs := f.runs[repoPath]
+	if limit > 0 && len(runs) > limit {
+		runs = runs[:limit]
+	}
+	return append([]models.WorkflowRun(nil), runs...), nil
+}
+
+// ListOpenReleasePRs gets seeded open release PRs for all repos in one simulated request
+func (f *FakeClient) ListOpenReleasePRs(repos []models.RepoInfo) (map[string]models.RepoPrStatus, error) {
+	f.sleep()
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	find := func(repoPath, head, base string) *models.GhPr {
+		for _, p := range f.prs[repoPath] {
+			if p.headBranch == head && p.baseBranch == base && p.pr.State == "open" {
+				pr := p.pr
+				return &pr
+			}
+		}
+		return nil
+	}
+
+	result := make(map[string]models.RepoPrStatus, len(repos))
+	for _, r := range repos {
+		var status models.RepoPrStatus
+		for _, prType := range r.Flow.PrTypes() {
+			status.PRs = append(status.PRs, models.ReleasePR{
+				PrType: prType,
+				PR:     find(r.Path, prType.HeadBranch(r.MainBranch), prType.BaseBranch(r.MainBranch)),
+			})
+		}
+		result[r.Path] = status
+	}
+	return result, nil
+}
+
+// GetWorkflowRunJobs gets seeded jobs for a workflow run
+func (f *FakeClient) GetWorkflowRunJobs(repoPath string, runID uint64) ([]models.WorkflowJob, error) {
+	f.sleep()
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]models.WorkflowJob(nil), f.jobs[runID]...), nil
+}
@@ -0,0 +1,257 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/wahlandcase/attuned.prmanager/internal/models"
+)
+
+// GhClient implements Client by shelling out to the gh CLI
+type GhClient struct{}
+
+// NewGhClient creates a Client backed by the gh CLI
+func NewGhClient() *GhClient {
+	return &GhClient{}
+}
+
+// CheckAuth verifies gh CLI is authenticated
+func (c *GhClient) CheckAuth() error {
+	cmd := exec.Command("gh", "auth", "status")
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("not authenticated with GitHub CLI. Run 'gh auth login' first")
+	}
+	return nil
+}
+
+// GetExistingPR gets an existing open PR for the given head -> base branch
+func (c *GhClient) GetExistingPR(repoPath, headBranch, baseBranch string) (*models.GhPr, error) {
+	cmd := exec.Command("gh", "pr", "list",
+		"--head", headBranch,
+		"--base", baseBranch,
+		"--state", "open",
+		"--json", "number,url,title,state",
+	)
+	cmd.Dir = repoPath
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("gh pr list failed: %s", string(output))
+	}
+
+	var prs []models.GhPr
+	if err := json.Unmarshal(output, &prs); err != nil {
+		return nil, fmt.Errorf("failed to parse gh pr list output: %w", err)
+	}
+
+	if len(prs) == 0 {
+		return nil, nil
+	}
+
+	return &prs[0], nil
+}
+
+// CreatePR creates a new pull request
+func (c *GhClient) CreatePR(repoPath, headBranch, baseBranch, title, body string) (*models.GhPr, error) {
+	cmd := exec.Command("gh", "pr", "create",
+		"--head", headBranch,
+		"--base", baseBranch,
+		"--title", title,
+		"--body", body,
+	)
+	cmd.Dir = repoPath
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("gh pr create failed: %s", string(output))
+	}
+
+	// gh pr create outputs the URL
+	url := strings.TrimSpace(string(output))
+
+	// Extract PR number from URL (e.g., https://github.com/org/repo/pull/123)
+	parts := strings.Split(url, "/")
+	var number uint64
+	if len(parts) > 0 {
+		number, _ = strconv.ParseUint(parts[len(parts)-1], 10, 64)
+	}
+
+	return &models.GhPr{
+		Number: number,
+		URL:    url,
+		Title:  title,
+		State:  "open",
+	}, nil
+}
+
+// UpdatePR updates an existing PR's title and body
+func (c *GhClient) UpdatePR(repoPath string, prNumber uint64, title, body string) (*models.GhPr, error) {
+	cmd := exec.Command("gh", "pr", "edit",
+		strconv.FormatUint(prNumber, 10),
+		"--title", title,
+		"--body", body,
+	)
+	cmd.Dir = repoPath
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("gh pr edit failed: %s", string(output))
+	}
+
+	// Get the updated PR info
+	return c.GetPR(repoPath, prNumber)
+}
+
+// GetPR gets PR details by number
+func (c *GhClient) GetPR(repoPath string, prNumber uint64) (*models.GhPr, error) {
+	cmd := exec.Command("gh", "pr", "view",
+		strconv.FormatUint(prNumber, 10),
+		"--json", "number,url,title,state,body",
+	)
+	cmd.Dir = repoPath
+
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("gh pr view failed: %w", err)
+	}
+
+	var pr models.GhPr
+	if err := json.Unmarshal(output, &pr); err != nil {
+		return nil, fmt.Errorf("failed to parse gh pr view output: %w", err)
+	}
+
+	return &pr, nil
+}
+
+// AddLabelsAndReviewers adds labels and requests reviewers on a PR
+func (c *GhClient) AddLabelsAndReviewers(repoPath string, prNumber uint64, labels, reviewers []string) error {
+	args := []string{"pr", "edit", strconv.FormatUint(prNumber, 10)}
+	if len(labels) > 0 {
+		args = append(args, "--add-label", strings.Join(labels, ","))
+	}
+	if len(reviewers) > 0 {
+		args = append(args, "--add-reviewer", strings.Join(reviewers, ","))
+	}
+	cmd := exec.Command("gh", args...)
+	cmd.Dir = repoPath
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("gh pr edit failed: %s", string(output))
+	}
+
+	return nil
+}
+
+// MergePR merges a PR with the given strategy (merge, squash or rebase)
+func (c *GhClient) MergePR(repoPath string, prNumber uint64, opts models.MergeOptions) error {
+	args := []string{"pr", "merge",
+		strconv.FormatUint(prNumber, 10),
+		"--" + string(opts.Strategy),
+		"--delete-branch=" + strconv.FormatBool(opts.DeleteBranch),
+	}
+	// Rebase merges have no merge commit to describe
+	if opts.Strategy != models.MergeStrategyRebase {
+		if opts.Subject != "" {
+			args = append(args, "--subject", opts.Subject)
+		}
+		if opts.Body != "" {
+			args = append(args, "--body", opts.Body)
+		}
+	}
+	cmd := exec.Command("gh", args...)
+	cmd.Dir = repoPath
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("gh pr merge failed: %s", string(output))
+	}
+
+	return nil
+}
+
+// EnableAutoMerge turns on GitHub auto-merge with the given strategy
+func (c *GhClient) EnableAutoMerge(repoPath string, prNumber uint64, opts models.MergeOptions) error {
+	args := []string{"pr", "merge",
+		strconv.FormatUint(prNumber, 10),
+		"--auto",
+		"--" + string(opts.Strategy),
+	}
+	if opts.Strategy != models.MergeStrategyRebase {
+		if opts.Subject != "" {
+			args = append(args, "--subject", opts.Subject)
+		}
+		if opts.Body != "" {
+			args = append(args, "--body", opts.Body)
+		}
+	}
+	cmd := exec.Command("gh", args...)
+	cmd.Dir = repoPath
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("gh pr merge --auto failed: %s", string(output))
+	}
+
+	return nil
+}
+
+// ListWorkflowRuns lists recent workflow runs for a repo
+func (c *GhClient) ListWorkflowRuns(repoPath string, limit int) ([]models.WorkflowRun, error) {
+	cmd := exec.Command("gh", "run", "list",
+		"--json", "databaseId,displayTitle,workflowName,status,conclusion,headBranch,headSha,event,url,createdAt,updatedAt",
+		"--limit", strconv.Itoa(limit),
+	)
+	cmd.Dir = repoPath
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("gh run list failed: %s", string(output))
+	}
+
+	var runs []models.WorkflowRun
+	if err := json.Unmarshal(output, &runs); err != nil {
+		return nil, fmt.Errorf("failed to parse gh run list output: %w", err)
+	}
+
+	return runs, nil
+}
+
+// ListOpenReleasePRs gets open release PRs for all repos via batched `gh api graphql` calls
+func (c *GhClient) ListOpenReleasePRs(repos []models.RepoInfo) (map[string]models.RepoPrStatus, error) {
+	return listOpenReleasePRsGraphQL(repos, OriginRepo, func(query string) ([]byte, error) {
+		cmd := exec.Command("gh", "api", "graphql", "-f", "query="+query)
+		output, err := cmd.Output()
+		if err != nil && len(output) == 0 {
+			// gh exits non-zero on partial GraphQL errors but still prints the data
+			return nil, fmt.Errorf("gh api graphql failed: %w", err)
+		}
+		return output, nil
+	})
+}
+
+// GetWorkflowRunJobs gets the jobs for a specific workflow run
+func (c *GhClient) GetWorkflowRunJobs(repoPath string, runID uint64) ([]models.WorkflowJob, error) {
+	cmd := exec.Command("gh", "run", "view",
+		strconv.FormatUint(runID, 10),
+		"--json", "jobs",
+	)
+	cmd.Dir = repoPath
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("gh run view failed: %s", string(output))
+	}
+
+	var result struct {
+		Jobs []models.WorkflowJob `json:"jobs"`
+	}
+	if err := json.Unmarshal(output, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse gh run view output: %w", err)
+	}
+
+	return result.Jobs, nil
+}
@@ -0,0 +1,204 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/wahlandcase/attuned.prmanager/internal/models"
+)
+
+// graphqlBatchSize is the number of repositories queried per GraphQL request
+const graphqlBatchSize = 25
+
+// prFieldsFragment selects the PR fields mapped into models.GhPr
+const prFieldsFragment = `fragment prFields on PullRequest {
+  number
+  url
+  title
+  state
+  mergeable
+  reviewDecision
+  autoMergeRequest { enabledAt }
+  headRefOid
+  commits(last: 1) { nodes { commit { statusCheckRollup { state } } } }
+}`
+
+// enableAutoMergeMutation enables auto-merge on a PR (variables: input)
+const enableAutoMergeMutation = `mutation($input: EnablePullRequestAutoMergeInput!) {
+  enablePullRequestAutoMerge(input: $input) { clientMutationId }
+}`
+
+// graphqlError returns the first error in a GraphQL response body, if any
+func graphqlError(body []byte) error {
+	var resp struct {
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return fmt.Errorf("failed to parse GraphQL response: %w", err)
+	}
+	if len(resp.Errors) > 0 {
+		return fmt.Errorf("GraphQL mutation failed: %s", resp.Errors[0].Message)
+	}
+	return nil
+}
+
+// repoQuery is a repo to look up along with its resolved owner/name
+type repoQuery struct {
+	repo  models.RepoInfo
+	owner string
+	name  string
+}
+
+// buildOpenPRsQuery builds one query fetching every promotion of every repo's flow using aliases
+func buildOpenPRsQuery(batch []repoQuery) string {
+	var b strings.Builder
+	b.WriteString("query {\n")
+	for i, q := range batch {
+		fmt.Fprintf(&b, "  r%d: repository(owner: %s, name: %s) {\n", i, strconv.Quote(q.owner), strconv.Quote(q.name))
+		for j, prType := range q.repo.Flow.PrTypes() {
+			fmt.Fprintf(&b, "    p%d: pullRequests(states: OPEN, headRefName: %s, baseRefName: %s, first: 1) { nodes { ...prFields } }\n",
+				j, strconv.Quote(prType.HeadBranch(q.repo.MainBranch)), strconv.Quote(prType.BaseBranch(q.repo.MainBranch)))
+		}
+		b.WriteString("  }\n")
+	}
+	b.WriteString("}\n")
+	b.WriteString(prFieldsFragment)
+	return b.String()
+}
+
+// graphqlPR is the PullRequest shape selected by prFieldsFragment
+type graphqlPR struct {
+	Number         uint64 `json:"number"`
+	URL            string `json:"url"`
+	Title          string `json:"title"`
+	State          string `json:"state"`
+	Mergeable      string `json:"mergeable"`
+	ReviewDecision string `json:"reviewDecision"`
+	AutoMerge      *struct {
+		EnabledAt string `json:"enabledAt"`
+	} `json:"autoMergeRequest"`
+	HeadRefOid string `json:"headRefOid"`
+	Commits    struct {
+		Nodes []struct {
+			Commit struct {
+				StatusCheckRollup *struct {
+					State string `json:"state"`
+				} `json:"statusCheckRollup"`
+			} `json:"commit"`
+		} `json:"nodes"`
+	} `json:"commits"`
+}
+
+func (p graphqlPR) toGhPr() *models.GhPr {
+	pr := &models.GhPr{
+		Number:         p.Number,
+		URL:            p.URL,
+		Title:          p.Title,
+		State:          p.State,
+		Mergeable:      p.Mergeable,
+		ReviewDecision: p.ReviewDecision,
+		AutoMerge:      p.AutoMerge != nil,
+		HeadSHA:        p.HeadRefOid,
+	}
+	if len(p.Commits.Nodes) > 0 && p.Commits.Nodes[0].Commit.StatusCheckRollup != nil {
+		pr.ChecksState = p.Commits.Nodes[0].Commit.StatusCheckRollup.State
+	}
+	return pr
+}
+
+// parseOpenPRsResponse maps a GraphQL response back onto the repos of a batch.
+// Repos that came back null (not found, no access) are omitted.
+func parseOpenPRsResponse(batch []repoQuery, body []byte) (map[string]models.RepoPrStatus, error) {
+	var resp struct {
+		Data   map[string]map[string]*struct{ Nodes []graphqlPR } `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse GraphQL response: %w", err)
+	}
+	if resp.Data == nil && len(resp.Errors) > 0 {
+		return nil, fmt.Errorf("GraphQL query failed: %s", resp.Errors[0].Message)
+	}
+
+	first := func(conn *struct{ Nodes []graphqlPR }) *models.GhPr {
+		if conn == nil || len(conn.Nodes) == 0 {
+			return nil
+		}
+		return conn.Nodes[0].toGhPr()
+	}
+
+	result := make(map[string]models.RepoPrStatus, len(batch))
+	for i, q := range batch {
+		repoData, ok := resp.Data["r"+strconv.Itoa(i)]
+		if !ok || repoData == nil {
+			continue
+		}
+		var status models.RepoPrStatus
+		for j, prType := range q.repo.Flow.PrTypes() {
+			status.PRs = append(status.PRs, models.ReleasePR{PrType: prType, PR: first(repoData["p"+strconv.Itoa(j)])})
+		}
+		result[q.repo.Path] = status
+	}
+	return result, nil
+}
+
+// listOpenReleasePRsGraphQL resolves repos, splits them into batches, and runs one query per batch
+// concurrently via exec. Repos that can't be resolved or aren't returned are omitted from the result.
+func listOpenReleasePRsGraphQL(repos []models.RepoInfo, resolve func(repoPath string) (string, error), exec func(query string) ([]byte, error)) (map[string]models.RepoPrStatus, error) {
+	var queries []repoQuery
+	for _, r := range repos {
+		slug, err := resolve(r.Path)
+		if err != nil {
+			continue // Skip repos without a GitHub origin
+		}
+		owner, name, _ := strings.Cut(slug, "/")
+		queries = append(queries, repoQuery{repo: r, owner: owner, name: name})
+	}
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		firstErr error
+	)
+	result := make(map[string]models.RepoPrStatus, len(queries))
+
+	for start := 0; start < len(queries); start += graphqlBatchSize {
+		batch := queries[start:min(start+graphqlBatchSize, len(queries))]
+		wg.Add(1)
+		go func(batch []repoQuery) {
+			defer wg.Done()
+
+			body, err := exec(buildOpenPRsQuery(batch))
+			var statuses map[string]models.RepoPrStatus
+			if err == nil {
+				statuses, err = parseOpenPRsResponse(batch, body)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				return
+			}
+			for path, status := range statuses {
+				result[path] = status
+			}
+		}(batch)
+	}
+	wg.Wait()
+
+	// Only fail if nothing came back at all
+	if len(result) == 0 && firstErr != nil {
+		return nil, firstErr
+	}
+	return result, nil
+}
@@ -1,5 +1,7 @@
 package models
 
+import "encoding/json"
+
 // BatchStatus represents the status of a batch PR operation for a single repo
 type BatchStatus interface {
 	isBatchStatus()
@@ -84,3 +86,42 @@ func GetStatusReason(s BatchStatus) string {
 	}
 	return ""
 }
+
+// StatusName returns "created", "updated", "skipped" or "failed"
+func StatusName(s BatchStatus) string {
+	switch s.(type) {
+	case batchStatusCreated:
+		return "created"
+	case batchStatusUpdated:
+		return "updated"
+	case batchStatusSkipped:
+		return "skipped"
+	default:
+		return "failed"
+	}
+}
+
+// MarshalJSON encodes the result for headless output (e.g., attpr batch --json)
+func (r BatchResult) MarshalJSON() ([]byte, error) {
+	tickets := r.Tickets
+	if tickets == nil {
+		tickets = []string{}
+	}
+	return json.Marshal(struct {
+		Repo    string   `json:"repo"`
+		Path    string   `json:"path"`
+		Group   string   `json:"group,omitempty"`
+		Status  string   `json:"status"`
+		Reason  string   `json:"reason,omitempty"`
+		URL     *string  `json:"url"`
+		Tickets []string `json:"tickets"`
+	}{
+		Repo:    r.Repo.DisplayName,
+		Path:    r.Repo.Path,
+		Group:   r.Repo.Group,
+		Status:  StatusName(r.Status),
+		Reason:  GetStatusReason(r.Status),
+		URL:     r.PrURL,
+		Tickets: tickets,
+	})
+}
@@ -0,0 +1,79 @@
+package models
+
+import (
+	"regexp"
+	"strings"
+)
+
+// conventionalRe matches a conventional commit subject: type(scope)!: description
+var conventionalRe = regexp.MustCompile(`^(\w+)(?:\(([^)]*)\))?(!)?:\s*(.+)$`)
+
+// ConventionalCommit is a commit subject parsed as a conventional commit
+type ConventionalCommit struct {
+	// Type is the lowercased commit type (e.g., "feat", "fix", "chore")
+	Type string
+	// Scope is the optional scope in parentheses (e.g., "api")
+	Scope string
+	// Description is the subject after the colon
+	Description string
+	// Breaking is set by "!" before the colon or a BREAKING CHANGE footer
+	Breaking bool
+}
+
+// Conventional parses the commit's subject as a conventional commit. ok is false for
+// subjects that don't follow the format; Description is then the whole subject.
+func (c CommitInfo) Conventional() (cc ConventionalCommit, ok bool) {
+	m := conventionalRe.FindStringSubmatch(strings.TrimSpace(c.Message))
+	if m == nil {
+		return ConventionalCommit{Description: c.Message, Breaking: c.Breaking}, false
+	}
+	return ConventionalCommit{
+		Type:        strings.ToLower(m[1]),
+		Scope:       m[2],
+		Description: m[4],
+		Breaking:    m[3] == "!" || c.Breaking,
+	}, true
+}
+
+// ChangelogEntry is one commit in a changelog group
+type ChangelogEntry struct {
+	Commit CommitInfo
+	ConventionalCommit
+}
+
+// Changelog groups commits by conventional commit type
+type Changelog struct {
+	Breaking []ChangelogEntry
+	Features []ChangelogEntry
+	Fixes    []ChangelogEntry
+	Other    []ChangelogEntry
+}
+
+// BuildChangelog groups commits into breaking changes, features, fixes and everything else.
+// Merge commits are left out, and breaking changes are only listed under Breaking.
+func BuildChangelog(commits []CommitInfo) Changelog {
+	var cl Changelog
+	for _, c := range commits {
+		if c.IsMerge() {
+			continue
+		}
+		cc, _ := c.Conventional()
+		entry := ChangelogEntry{Commit: c, ConventionalCommit: cc}
+		switch {
+		case cc.Breaking:
+			cl.Breaking = append(cl.Breaking, entry)
+		case cc.Type == "feat":
+			cl.Features = append(cl.Features, entry)
+		case cc.Type == "fix":
+			cl.Fixes = append(cl.Fixes, entry)
+		default:
+			cl.Other = append(cl.Other, entry)
+		}
+	}
+	return cl
+}
+
+// IsEmpty returns true if the changelog has no entries
+func (cl Changelog) IsEmpty() bool {
+	return len(cl.Breaking)+len(cl.Features)+len(cl.Fixes)+len(cl.Other) == 0
+}
@@ -1,5 +1,7 @@
 package models
 
+import "time"
+
 // CommitInfo contains information about a git commit
 type CommitInfo struct {
 	// Hash is the short commit hash (7 characters)
@@ -8,6 +10,19 @@ type CommitInfo struct {
 	Message string
 	// Tickets are Linear ticket IDs found in the message (e.g., ["ATT-123", "ATT-456"])
 	Tickets []string
+	// Author is the commit author's name
+	Author string
+	// Date is the author date
+	Date time.Time
+	// Parents is the number of parent commits (more than 1 for merge commits)
+	Parents int
+	// Breaking is true if the message has a BREAKING CHANGE footer
+	Breaking bool
+}
+
+// IsMerge returns true for merge commits
+func (c CommitInfo) IsMerge() bool {
+	return c.Parents > 1
 }
 
 // NewCommitInfo creates a new CommitInfo
@@ -0,0 +1,115 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MainStage is the stage name that resolves to each repo's detected main branch ("main" or "master")
+const MainStage = "main"
+
+// DefaultStages is the release flow used when none is configured
+var DefaultStages = []string{"dev", "staging", MainStage}
+
+// Flow is the ordered list of release branches changes are promoted through
+type Flow struct {
+	Stages []string
+}
+
+// DefaultFlow returns the dev → staging → main flow
+func DefaultFlow() Flow {
+	return Flow{Stages: append([]string(nil), DefaultStages...)}
+}
+
+// StageBranch resolves a stage name to a branch name for a repo
+func StageBranch(stage, mainBranch string) string {
+	if stage == MainStage {
+		return mainBranch
+	}
+	return stage
+}
+
+// Validate checks the flow has at least two distinct, non-empty stages
+func (f Flow) Validate() error {
+	if len(f.Stages) < 2 {
+		return fmt.Errorf("flow needs at least 2 stages, got %d", len(f.Stages))
+	}
+	seen := make(map[string]bool)
+	for _, s := range f.Stages {
+		if strings.TrimSpace(s) == "" {
+			return fmt.Errorf("flow has an empty stage name")
+		}
+		if seen[s] {
+			return fmt.Errorf("flow stage %q appears more than once", s)
+		}
+		seen[s] = true
+	}
+	return nil
+}
+
+// PrTypes returns one PrType per promotion, in flow order
+func (f Flow) PrTypes() []PrType {
+	var types []PrType
+	for i := 0; i+1 < len(f.Stages); i++ {
+		types = append(types, PrType{
+			Head:  f.Stages[i],
+			Base:  f.Stages[i+1],
+			Index: i,
+			Final: i+2 == len(f.Stages),
+		})
+	}
+	return types
+}
+
+// Resolve finds the promotion in this flow that corresponds to target (usually taken from
+// another flow). Matches by base stage name, then final-to-final.
+func (f Flow) Resolve(target PrType) (PrType, bool) {
+	types := f.PrTypes()
+	for _, t := range types {
+		if t.Base == target.Base {
+			return t, true
+		}
+	}
+	if target.Final && len(types) > 0 {
+		return types[len(types)-1], true
+	}
+	return PrType{}, false
+}
+
+// FindPrType finds a promotion by its slug (e.g., "dev-staging"), case-insensitively
+func (f Flow) FindPrType(slug string) (PrType, error) {
+	var valid []string
+	for _, t := range f.PrTypes() {
+		if strings.EqualFold(t.Slug(), slug) {
+			return t, nil
+		}
+		valid = append(valid, t.Slug())
+	}
+	return PrType{}, fmt.Errorf("unknown PR type %q (expected one of: %s)", slug, strings.Join(valid, ", "))
+}
+
+// ResolveStage finds the stage in this flow that corresponds to stage idx of from.
+// Matches by name first, then last-to-last, then by position.
+func (f Flow) ResolveStage(from Flow, idx int) (string, bool) {
+	if idx < 0 || idx >= len(from.Stages) || len(f.Stages) == 0 {
+		return "", false
+	}
+	stage := from.Stages[idx]
+	for _, s := range f.Stages {
+		if s == stage {
+			return s, true
+		}
+	}
+	if idx == len(from.Stages)-1 {
+		return f.Stages[len(f.Stages)-1], true
+	}
+	if idx < len(f.Stages)-1 {
+		return f.Stages[idx], true
+	}
+	return "", false
+}
+
+// String returns the flow as "dev → staging → main"
+func (f Flow) String() string {
+	return strings.Join(f.Stages, " → ")
+}
@@ -6,10 +6,36 @@ type GhPr struct {
 	URL    string `json:"url"`
 	Title  string `json:"title"`
 	State  string `json:"state"`
+	// Body is only populated by GetPR
+	Body string `json:"body,omitempty"`
+
+	// Merge readiness (only populated by batched open-PR queries)
+	Mergeable      string `json:"mergeable,omitempty"`      // MERGEABLE, CONFLICTING or UNKNOWN
+	ReviewDecision string `json:"reviewDecision,omitempty"` // APPROVED, CHANGES_REQUESTED, REVIEW_REQUIRED or "" (no review required)
+	ChecksState    string `json:"checksState,omitempty"`    // SUCCESS, FAILURE, ERROR, PENDING, EXPECTED or "" (no checks)
+	AutoMerge      bool   `json:"autoMerge,omitempty"`      // GitHub auto-merge is enabled
+	HeadSHA        string `json:"headSha,omitempty"`        // Commit the head branch points at
 }
 
-// RepoPrStatus contains info about open PRs for a repo
+// RepoPrStatus contains info about open release PRs for a repo
 type RepoPrStatus struct {
-	DevToStaging  *GhPr
-	StagingToMain *GhPr
+	// PRs has one entry per promotion in the repo's flow, in flow order
+	PRs []ReleasePR
+}
+
+// ReleasePR is the open PR (if any) for one promotion of a repo's flow
+type ReleasePR struct {
+	PrType PrType
+	// PR is nil when no PR is open for this promotion
+	PR *GhPr
+}
+
+// HasAny returns true if any promotion has an open PR
+func (s RepoPrStatus) HasAny() bool {
+	for _, r := range s.PRs {
+		if r.PR != nil {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,116 @@
+package models
+
+import (
+	"strings"
+	"text/template"
+)
+
+// MergeStrategy is how a PR's commits land on the base branch
+type MergeStrategy string
+
+// Merge strategies supported by GitHub
+const (
+	MergeStrategyMerge  MergeStrategy = "merge"
+	MergeStrategySquash MergeStrategy = "squash"
+	MergeStrategyRebase MergeStrategy = "rebase"
+)
+
+// ParseMergeStrategy validates a strategy name ("" means merge)
+func ParseMergeStrategy(s string) (MergeStrategy, bool) {
+	switch MergeStrategy(s) {
+	case "", MergeStrategyMerge:
+		return MergeStrategyMerge, true
+	case MergeStrategySquash, MergeStrategyRebase:
+		return MergeStrategy(s), true
+	}
+	return "", false
+}
+
+// MergeMethod is how release PRs of one promotion are merged
+type MergeMethod struct {
+	// Strategy is merge, squash or rebase ("" = merge)
+	Strategy MergeStrategy
+	// DeleteBranch deletes the head branch after merging
+	DeleteBranch bool
+	// SubjectTemplate and BodyTemplate format the merge commit (nil = GitHub's default)
+	SubjectTemplate *template.Template
+	BodyTemplate    *template.Template
+}
+
+// MergeSettings holds a repo's merge method for each promotion in its flow
+type MergeSettings struct {
+	// Default applies to promotions without their own entry
+	Default MergeMethod
+	// Promotions are keyed by PrType.Slug() (e.g., "dev-staging")
+	Promotions map[string]MergeMethod
+}
+
+// For returns the merge method for a promotion
+func (s MergeSettings) For(prType PrType) MergeMethod {
+	if m, ok := s.Promotions[prType.Slug()]; ok {
+		return m
+	}
+	return s.Default
+}
+
+// MergeData is the data available to merge commit templates
+type MergeData struct {
+	// Title is the PR title
+	Title string
+	// Number is the PR number
+	Number uint64
+	// Repo is the repo display name
+	Repo string
+	// Head and Base are the PR's branch names
+	Head string
+	Base string
+}
+
+// MergeOptions are the resolved options for merging one PR
+type MergeOptions struct {
+	Strategy     MergeStrategy
+	DeleteBranch bool
+	// Subject and Body of the merge commit ("" = GitHub's default; ignored for rebase)
+	Subject string
+	Body    string
+}
+
+// Options renders the merge commit templates for one PR
+func (m MergeMethod) Options(data MergeData) (MergeOptions, error) {
+	opts := MergeOptions{Strategy: m.Strategy, DeleteBranch: m.DeleteBranch}
+	if opts.Strategy == "" {
+		opts.Strategy = MergeStrategyMerge
+	}
+
+	render := func(tmpl *template.Template) (string, error) {
+		if tmpl == nil {
+			return "", nil
+		}
+		var b strings.Builder
+		if err := tmpl.Execute(&b, data); err != nil {
+			return "", err
+		}
+		return b.String(), nil
+	}
+
+	var err error
+	if opts.Subject, err = render(m.SubjectTemplate); err != nil {
+		return opts, err
+	}
+	if opts.Body, err = render(m.BodyTemplate); err != nil {
+		return opts, err
+	}
+	return opts, nil
+}
+
+// Describe returns a short description for confirmation screens (e.g., "squash, delete branch")
+func (m MergeMethod) Describe() string {
+	desc := string(m.Strategy)
+	if desc == "" {
+		desc = string(MergeStrategyMerge)
+	}
+	if m.DeleteBranch {
+		desc += ", delete branch"
+	}
+	return desc
+}
@@ -1,5 +1,7 @@
 package models
 
+import "slices"
+
 // MergePrEntry represents an entry for a PR in the merge selection list
 type MergePrEntry struct {
 	// Repo is the repository info
@@ -12,4 +14,62 @@ type MergePrEntry struct {
 	URL string
 	// PrType is the PR type
 	PrType PrType
+	// Merge readiness from the open-PR query (see GhPr)
+	Mergeable      string
+	ReviewDecision string
+	ChecksState    string
+	// AutoMerge is true when GitHub auto-merge is enabled on the PR
+	AutoMerge bool
+}
+
+// Reasons a PR can't be merged yet, as returned by MergeBlockers
+const (
+	BlockerConflicts        = "conflicts"
+	BlockerChecksFailing    = "checks failing"
+	BlockerChecksPending    = "checks pending"
+	BlockerChangesRequested = "changes requested"
+	BlockerReviewRequired   = "review required"
+)
+
+// MergeBlockers returns why the PR can't be merged yet (empty if it's ready)
+func (e MergePrEntry) MergeBlockers() []string {
+	var blockers []string
+	if e.Mergeable == "CONFLICTING" {
+		blockers = append(blockers, BlockerConflicts)
+	}
+	switch e.ChecksState {
+	case "FAILURE", "ERROR":
+		blockers = append(blockers, BlockerChecksFailing)
+	case "PENDING", "EXPECTED":
+		blockers = append(blockers, BlockerChecksPending)
+	}
+	switch e.ReviewDecision {
+	case "CHANGES_REQUESTED":
+		blockers = append(blockers, BlockerChangesRequested)
+	case "REVIEW_REQUIRED":
+		blockers = append(blockers, BlockerReviewRequired)
+	}
+	return blockers
+}
+
+// MergeBlockersExcept returns the blockers other than the given ones (those that can be waited out)
+func (e MergePrEntry) MergeBlockersExcept(waitable ...string) []string {
+	var blockers []string
+	for _, b := range e.MergeBlockers() {
+		if !slices.Contains(waitable, b) {
+			blockers = append(blockers, b)
+		}
+	}
+	return blockers
+}
+
+// AutoMergeBlockers returns the blockers that auto-merge can't wait out (pending checks and
+// required reviews can still pass; conflicts, failing checks and requested changes can't)
+func (e MergePrEntry) AutoMergeBlockers() []string {
+	return e.MergeBlockersExcept(BlockerChecksPending, BlockerReviewRequired)
+}
+
+// MergeMethod returns how this PR will be merged, per the repo's merge settings
+func (e MergePrEntry) MergeMethod() MergeMethod {
+	return e.Repo.Settings.Merge.For(e.PrType)
 }
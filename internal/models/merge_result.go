@@ -1,5 +1,7 @@
 package models
 
+import "encoding/json"
+
 // MergeResult represents the result of merging a single PR
 type MergeResult struct {
 	// RepoName (e.g., "frontend/attuned-web")
@@ -16,4 +18,33 @@ type MergeResult struct {
 	Error *string
 	// URL is the PR URL
 	URL string
+	// AutoMerge is true when GitHub auto-merge was enabled instead of merging now
+	AutoMerge bool
+}
+
+// MarshalJSON encodes the result for headless output (e.g., attpr merge --json)
+func (r MergeResult) MarshalJSON() ([]byte, error) {
+	status := "merged"
+	if !r.Success {
+		status = "failed"
+	} else if r.AutoMerge {
+		status = "auto-merge"
+	}
+	return json.Marshal(struct {
+		Repo   string  `json:"repo"`
+		Number uint64  `json:"number"`
+		Title  string  `json:"title"`
+		Type   string  `json:"type"`
+		URL    string  `json:"url"`
+		Status string  `json:"status"`
+		Error  *string `json:"error,omitempty"`
+	}{
+		Repo:   r.RepoName,
+		Number: r.PrNumber,
+		Title:  r.PrTitle,
+		Type:   r.PrType.Slug(),
+		URL:    r.URL,
+		Status: status,
+		Error:  r.Error,
+	})
 }
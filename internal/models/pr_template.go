@@ -0,0 +1,84 @@
+package models
+
+import (
+	"strings"
+	"text/template"
+	"time"
+)
+
+// PRTemplate formats a release PR's title and body
+type PRTemplate struct {
+	// Title formats the PR title (nil = the entered title as-is)
+	Title *template.Template
+	// Body formats the PR body (nil = the default tickets list)
+	Body *template.Template
+}
+
+// PRTemplates holds a repo's PR templates for each promotion in its flow
+type PRTemplates struct {
+	// Default applies to promotions without their own entry
+	Default PRTemplate
+	// Promotions are keyed by PrType.Slug() (e.g., "dev-staging")
+	Promotions map[string]PRTemplate
+}
+
+// For returns the PR templates for a promotion
+func (s PRTemplates) For(prType PrType) PRTemplate {
+	if t, ok := s.Promotions[prType.Slug()]; ok {
+		return t
+	}
+	return s.Default
+}
+
+// TicketLink is a ticket ID and its tracker URL
+type TicketLink struct {
+	ID  string
+	URL string
+}
+
+// PRData is the data available to PR title and body templates
+type PRData struct {
+	// Title is the title entered in attpr
+	Title string
+	// Repo is the repo display name
+	Repo string
+	// Head and Base are the PR's branch names
+	Head string
+	Base string
+	// Commits are the commits being promoted, newest first
+	Commits []CommitInfo
+	// Tickets are the tickets found in the commits, sorted by ID
+	Tickets []TicketLink
+	// Authors are the commit authors, sorted by name
+	Authors []string
+	// Date is when the PR is created or updated
+	Date time.Time
+	// Sprint is the current sprint number (0 if [sprint] isn't configured)
+	Sprint int
+	// Changelog is the changelog grouped by conventional commit type ("" when turned off)
+	Changelog string
+	// DefaultBody is the body attpr writes without a body template (tickets, then changelog)
+	DefaultBody string
+}
+
+// Render applies the templates to data, falling back to the entered title and default body
+func (t PRTemplate) Render(data PRData) (title, body string, err error) {
+	render := func(tmpl *template.Template, fallback string) (string, error) {
+		if tmpl == nil {
+			return fallback, nil
+		}
+		var b strings.Builder
+		if err := tmpl.Execute(&b, data); err != nil {
+			return "", err
+		}
+		return b.String(), nil
+	}
+
+	if title, err = render(t.Title, data.Title); err != nil {
+		return "", "", err
+	}
+	if body, err = render(t.Body, data.DefaultBody); err != nil {
+		return "", "", err
+	}
+	return strings.TrimSpace(title), strings.TrimSpace(body), nil
+}
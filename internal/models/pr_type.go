@@ -2,58 +2,42 @@ package models
 
 import "fmt"
 
-// PrType represents the type of pull request to create
-type PrType int
-
-const (
-	// DevToStaging represents dev -> staging
-	DevToStaging PrType = iota
-	// StagingToMain represents staging -> main/master
-	StagingToMain
-)
+// PrType represents a promotion PR from one stage of a release flow to the next
+type PrType struct {
+	// Head is the stage being promoted (e.g., "dev")
+	Head string
+	// Base is the stage receiving the changes (e.g., "staging")
+	Base string
+	// Index is the position of this promotion in its flow (0 = first)
+	Index int
+	// Final is true for the promotion into the last stage (production release)
+	Final bool
+}
 
 // BaseBranch returns the base branch for this PR type
 func (p PrType) BaseBranch(mainBranch string) string {
-	switch p {
-	case DevToStaging:
-		return "staging"
-	case StagingToMain:
-		return mainBranch
-	default:
-		return ""
-	}
+	return StageBranch(p.Base, mainBranch)
 }
 
 // HeadBranch returns the head branch for this PR type
-func (p PrType) HeadBranch() string {
-	switch p {
-	case DevToStaging:
-		return "dev"
-	case StagingToMain:
-		return "staging"
-	default:
-		return ""
-	}
+func (p PrType) HeadBranch(mainBranch string) string {
+	return StageBranch(p.Head, mainBranch)
 }
 
 // Display returns a display string for this PR type
 func (p PrType) Display(mainBranch string) string {
-	switch p {
-	case DevToStaging:
-		return "dev → staging"
-	case StagingToMain:
-		return fmt.Sprintf("staging → %s", mainBranch)
-	default:
-		return ""
-	}
+	return fmt.Sprintf("%s → %s", p.HeadBranch(mainBranch), p.BaseBranch(mainBranch))
+}
+
+// Slug returns the PR type as used on the command line (e.g., "dev-staging")
+func (p PrType) Slug() string {
+	return p.Head + "-" + p.Base
 }
 
 // DefaultTitle returns the default PR title
 func (p PrType) DefaultTitle(mainBranch string) string {
-	switch p {
-	case StagingToMain:
+	if p.Final {
 		return "Sprint # "
-	default:
-		return p.Display(mainBranch)
 	}
+	return p.Display(mainBranch)
 }
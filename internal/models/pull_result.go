@@ -9,12 +9,37 @@ const (
 	PullSkippedNoBranch                   // Branch doesn't exist
 	PullSkippedDirty                      // Has uncommitted changes
 	PullFailed                            // Pull failed
+	PullStashRestored                     // Local changes stashed, pulled, and re-applied
+	PullStashConflict                     // Pulled, but local changes didn't re-apply and were left stashed
+	PullAhead                             // Local branch has commits origin doesn't (nothing to pull)
+	PullBehind                            // Origin has new commits, but fast-forwarding failed
+	PullDiverged                          // Local and origin both have commits the other lacks
+	PullReset                             // Local branch was reset to origin (after PullAhead/PullDiverged)
+	PullCreated                           // Local branch didn't exist and was created from origin
 )
 
 // PullResult represents the result of pulling a single repo
 type PullResult struct {
 	Repo        RepoInfo
 	Status      PullStatus
-	CommitCount int    // Only for PullUpdated
-	Error       string // Only for PullFailed
+	CommitCount int    // For PullUpdated and the stash statuses
+	Error       string // For PullFailed, PullBehind and PullStashConflict
+	StashRef    string // Only for PullStashConflict (e.g. "stash@{0}")
+	// Branch is the local branch that was pulled
+	Branch string
+	// OriginalBranch is what was checked out before pulling (a commit hash if detached)
+	OriginalBranch string
+	// Ahead/Behind count commits only on the local branch / only on origin
+	// (for PullAhead, PullBehind and PullDiverged)
+	Ahead  int
+	Behind int
+	// LocalCommits are the local-only commits, newest first (for PullAhead and PullDiverged)
+	LocalCommits []CommitInfo
+	// ResetFrom is the commit the branch pointed at before PullReset
+	ResetFrom string
+}
+
+// CanResetToOrigin returns true if the local branch has commits origin doesn't
+func (r PullResult) CanResetToOrigin() bool {
+	return r.Status == PullAhead || r.Status == PullDiverged
 }
@@ -0,0 +1,11 @@
+package models
+
+// RepoGroup is a named set of repos discovered from globs and explicit paths
+type RepoGroup struct {
+	// Name is used as the display name prefix (e.g., "frontend")
+	Name string
+	// Globs are patterns relative to the attuned dir (e.g., "frontend/*")
+	Globs []string
+	// Paths are explicit repo paths (absolute, "~/", or relative to the attuned dir)
+	Paths []string
+}
@@ -12,19 +12,12 @@ type RepoInfo struct {
 	MainBranch string
 	// ParentRepo name if this is a nested repo (e.g., "attuned-services")
 	ParentRepo *string
-}
-
-// IsFrontend returns true if this repo belongs to the frontend category
-func (r RepoInfo) IsFrontend() bool {
-	return strings.Contains(r.DisplayName, "frontend/") || strings.HasPrefix(r.DisplayName, "frontend")
-}
-
-// InColumn returns true if this repo belongs to the given column (0=frontend, 1=backend/other)
-func (r RepoInfo) InColumn(column int) bool {
-	if column == 0 {
-		return r.IsFrontend()
-	}
-	return !r.IsFrontend()
+	// Group is the name of the repo group it was discovered in (e.g., "frontend")
+	Group string
+	// Flow is the release flow for this repo (global or per-repo from config)
+	Flow Flow
+	// Settings are per-repo overrides from config
+	Settings RepoSettings
 }
 
 // NewRepoInfo creates a new RepoInfo
@@ -34,6 +27,7 @@ func NewRepoInfo(path, displayName, mainBranch string) RepoInfo {
 		DisplayName: displayName,
 		MainBranch:  mainBranch,
 		ParentRepo:  nil,
+		Flow:        DefaultFlow(),
 	}
 }
 
@@ -43,6 +37,12 @@ func (r RepoInfo) WithParent(parent string) RepoInfo {
 	return r
 }
 
+// MatchesName returns true if name is the repo's display name, or its last segment
+// when name has no "/"
+func (r RepoInfo) MatchesName(name string) bool {
+	return r.DisplayName == name || (!strings.Contains(name, "/") && r.ShortName() == name)
+}
+
 // ShortName returns just the last segment of DisplayName (after the last "/")
 func (r RepoInfo) ShortName() string {
 	if idx := strings.LastIndex(r.DisplayName, "/"); idx != -1 {
@@ -0,0 +1,39 @@
+package models
+
+import "regexp"
+
+// RepoSettings holds per-repo overrides resolved from config and the repo's .attpr.toml
+type RepoSettings struct {
+	// TicketRegex overrides the global ticket pattern (nil = use global)
+	TicketRegex *regexp.Regexp
+	// Tracker formats ticket links in PR bodies (nil = use global)
+	Tracker TicketTracker
+	// PR formats each promotion's PR title and body
+	PR PRTemplates
+	// Labels are added to every PR created or updated for this repo
+	Labels []string
+	// Reviewers are requested on every PR (users, or "org/team" for teams)
+	Reviewers []string
+	// Merge is how each promotion's PRs are merged
+	Merge MergeSettings
+	// MergeAfter lists repos (display name or last segment) whose PRs merge before this repo's
+	MergeAfter []string
+	// DeployWorkflow is the workflow whose base-branch run must succeed before dependents merge
+	DeployWorkflow string
+}
+
+// TicketRegexOr returns the repo's ticket pattern, or fallback if it has none
+func (s RepoSettings) TicketRegexOr(fallback *regexp.Regexp) *regexp.Regexp {
+	if s.TicketRegex != nil {
+		return s.TicketRegex
+	}
+	return fallback
+}
+
+// TrackerOr returns the repo's ticket tracker, or fallback if it has none
+func (s RepoSettings) TrackerOr(fallback TicketTracker) TicketTracker {
+	if s.Tracker != nil {
+		return s.Tracker
+	}
+	return fallback
+}
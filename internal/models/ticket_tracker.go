@@ -0,0 +1,10 @@
+package models
+
+// TicketTracker formats ticket references for PR bodies
+type TicketTracker interface {
+	// URL returns a link to the ticket ("" if the tracker can't build one)
+	URL(ticket string) string
+	// Reference returns the ticket as written in the PR body, with the tracker's closing
+	// keyword (e.g., "Closes [ATT-123](https://linear.app/...)" or "Closes #42")
+	Reference(ticket string) string
+}
@@ -9,6 +9,7 @@ type WorkflowRun struct {
 	Status       string    `json:"status"`
 	Conclusion   string    `json:"conclusion"`
 	HeadBranch   string    `json:"headBranch"`
+	HeadSHA      string    `json:"headSha"`
 	Event        string    `json:"event"`
 	URL          string    `json:"url"`
 	CreatedAt    time.Time `json:"createdAt"`
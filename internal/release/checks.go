@@ -0,0 +1,62 @@
+package release
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/wahlandcase/attuned.prmanager/internal/models"
+)
+
+// CheckState is the combined state of the workflow runs on a branch
+type CheckState int
+
+const (
+	// ChecksNone means no workflow has run on the branch
+	ChecksNone CheckState = iota
+	// ChecksRunning means at least one workflow is queued or in progress
+	ChecksRunning
+	// ChecksPassed means every workflow's latest run succeeded (or was skipped)
+	ChecksPassed
+	// ChecksFailed means at least one workflow's latest run failed or was cancelled
+	ChecksFailed
+)
+
+// BranchChecks summarizes the latest run of each workflow on a branch at the given head commit,
+// ignoring runs from earlier pushes. The returned detail names the running or failed workflows.
+func BranchChecks(runs []models.WorkflowRun, branch, headSHA string) (CheckState, string) {
+	var onBranch []models.WorkflowRun
+	for _, run := range runs {
+		if run.HeadBranch == branch && run.HeadSHA == headSHA {
+			onBranch = append(onBranch, run)
+		}
+	}
+	// Newest first, so the first run seen per workflow is its latest
+	sort.SliceStable(onBranch, func(i, j int) bool {
+		return onBranch[i].CreatedAt.After(onBranch[j].CreatedAt)
+	})
+
+	seen := make(map[string]bool)
+	var running, failed []string
+	for _, run := range onBranch {
+		if seen[run.WorkflowName] {
+			continue
+		}
+		seen[run.WorkflowName] = true
+		switch {
+		case run.Status != "completed":
+			running = append(running, run.WorkflowName)
+		case run.Conclusion != "success" && run.Conclusion != "skipped" && run.Conclusion != "neutral":
+			failed = append(failed, run.WorkflowName)
+		}
+	}
+
+	switch {
+	case len(failed) > 0:
+		return ChecksFailed, strings.Join(failed, ", ")
+	case len(running) > 0:
+		return ChecksRunning, strings.Join(running, ", ")
+	case len(seen) > 0:
+		return ChecksPassed, ""
+	}
+	return ChecksNone, ""
+}
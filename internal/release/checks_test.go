@@ -0,0 +1,78 @@
+package release
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wahlandcase/attuned.prmanager/internal/models"
+)
+
+func TestBranchChecks(t *testing.T) {
+	now := time.Now()
+	run := func(workflow, branch, sha, status, conclusion string, age time.Duration) models.WorkflowRun {
+		return models.WorkflowRun{WorkflowName: workflow, HeadBranch: branch, HeadSHA: sha,
+			Status: status, Conclusion: conclusion, CreatedAt: now.Add(-age)}
+	}
+
+	tests := []struct {
+		name   string
+		runs   []models.WorkflowRun
+		state  CheckState
+		detail string
+	}{
+		{
+			name:  "no runs",
+			state: ChecksNone,
+		},
+		{
+			name: "passed on head commit",
+			runs: []models.WorkflowRun{
+				run("CI", "dev", "new", "completed", "success", time.Minute),
+				run("Lint", "dev", "new", "completed", "skipped", time.Minute),
+			},
+			state: ChecksPassed,
+		},
+		{
+			name: "green run from an earlier push is ignored",
+			runs: []models.WorkflowRun{
+				run("CI", "dev", "old", "completed", "success", time.Hour),
+			},
+			state: ChecksNone,
+		},
+		{
+			name: "earlier push passed, head commit still running",
+			runs: []models.WorkflowRun{
+				run("CI", "dev", "old", "completed", "success", time.Hour),
+				run("CI", "dev", "new", "in_progress", "", time.Minute),
+			},
+			state:  ChecksRunning,
+			detail: "CI",
+		},
+		{
+			name: "latest run per workflow wins",
+			runs: []models.WorkflowRun{
+				run("CI", "dev", "new", "completed", "failure", time.Hour),
+				run("CI", "dev", "new", "completed", "success", time.Minute),
+			},
+			state: ChecksPassed,
+		},
+		{
+			name: "other branches are ignored",
+			runs: []models.WorkflowRun{
+				run("CI", "staging", "new", "completed", "failure", time.Minute),
+				run("Deploy", "dev", "new", "completed", "cancelled", time.Minute),
+			},
+			state:  ChecksFailed,
+			detail: "Deploy",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state, detail := BranchChecks(tt.runs, "dev", "new")
+			if state != tt.state || detail != tt.detail {
+				t.Errorf("BranchChecks() = (%v, %q), want (%v, %q)", state, detail, tt.state, tt.detail)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+// Package release runs release PR operations shared by the TUI and the headless subcommands
+package release
+
+import (
+	"fmt"
+	"regexp"
+	"sync"
+
+	"github.com/wahlandcase/attuned.prmanager/internal/git"
+	"github.com/wahlandcase/attuned.prmanager/internal/github"
+	"github.com/wahlandcase/attuned.prmanager/internal/models"
+)
+
+// CreateOptions controls how CreatePR builds a PR
+type CreateOptions struct {
+	// Title is the entered PR title (the repo's title template is applied on top)
+	Title string
+	// Tracker formats ticket links in the PR body (repos may override it)
+	Tracker models.TicketTracker
+	// Sprint is the current sprint number for PR templates (0 if unknown)
+	Sprint int
+	// Changelog adds a changelog grouped by conventional commit type to the PR body
+	Changelog bool
+	// TicketRegex is the global ticket pattern (repos may override it)
+	TicketRegex *regexp.Regexp
+	// Commits, when non-nil, are used instead of fetching and reading git (dry run)
+	Commits *[]models.CommitInfo
+	// SkipFetch reads commits from the remote-tracking branches as last fetched, without
+	// fetching (headless dry run)
+	SkipFetch bool
+	// Progress is called before each step (optional)
+	Progress func(step string)
+}
+
+// CreatePR promotes prType in one repo: fetches both branches, collects the commits between
+// them and creates or updates the PR. prType may come from another flow (e.g., the global
+// flow in batch mode) and is mapped onto the repo's own flow. Failures are reported in the
+// result's status rather than as an error.
+func CreatePR(client github.Client, repo models.RepoInfo, prType models.PrType, opts CreateOptions) models.BatchResult {
+	progress := func(step string) {
+		if opts.Progress != nil {
+			opts.Progress(step)
+		}
+	}
+	result := func(status models.BatchStatus) models.BatchResult {
+		return models.BatchResult{Repo: repo, Status: status}
+	}
+
+	// Map the promotion onto this repo's flow
+	repoType, ok := repo.Flow.Resolve(prType)
+	if !ok {
+		return result(models.Skipped(fmt.Sprintf("No %s promotion in this repo's flow", prType.Display("main"))))
+	}
+	headBranch := repoType.HeadBranch(repo.MainBranch)
+	baseBranch := repoType.BaseBranch(repo.MainBranch)
+
+	var commits []models.CommitInfo
+	if opts.Commits != nil {
+		commits = *opts.Commits
+	} else {
+		// Fetch branches
+		if !opts.SkipFetch {
+			progress("Fetching branches...")
+			if err := git.FetchBranches(repo.Path, []string{headBranch, baseBranch}); err != nil {
+				return result(models.Failed(err.Error()))
+			}
+		}
+
+		// Get commits
+		progress("Getting commits...")
+		var err error
+		commits, err = git.GetCommitsBetween(repo.Path, baseBranch, headBranch, repo.Settings.TicketRegexOr(opts.TicketRegex))
+		if err != nil {
+			return result(models.Failed(err.Error()))
+		}
+	}
+
+	if len(commits) == 0 {
+		return result(models.Skipped("No commits to merge"))
+	}
+
+	tickets := git.GetAllTickets(commits)
+
+	title, body, err := github.RenderPR(repo, repoType, opts.Title, commits, github.BodyOptions{
+		Tracker:   opts.Tracker,
+		Sprint:    opts.Sprint,
+		Changelog: opts.Changelog,
+	})
+	if err != nil {
+		return result(models.Failed("PR template: " + err.Error()))
+	}
+
+	// Create or update PR
+	progress("Creating PR...")
+	pr, updated, err := github.CreateOrUpdatePR(client, repo, headBranch, baseBranch, title, body)
+	if err != nil {
+		return result(models.Failed(err.Error()))
+	}
+
+	status := models.Created
+	if updated {
+		status = models.Updated
+	}
+
+	return models.BatchResult{
+		Repo:    repo,
+		Status:  status,
+		PrURL:   &pr.URL,
+		Tickets: tickets,
+	}
+}
+
+// CreatePRs runs CreatePR for each repo, up to workers at a time, and returns the results in
+// repo order. progress (optional) receives each repo's steps in place of opts.Progress.
+func CreatePRs(client github.Client, repos []models.RepoInfo, prType models.PrType, opts CreateOptions, workers int, progress func(repo models.RepoInfo, step string)) []models.BatchResult {
+	results := make([]models.BatchResult, len(repos))
+	sem := make(chan struct{}, max(workers, 1))
+	var wg sync.WaitGroup
+	for i, repo := range repos {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, repo models.RepoInfo) {
+			defer func() { <-sem; wg.Done() }()
+			repoOpts := opts
+			repoOpts.Progress = nil
+			if progress != nil {
+				repoOpts.Progress = func(step string) { progress(repo, step) }
+			}
+			results[i] = CreatePR(client, repo, prType, repoOpts)
+		}(i, repo)
+	}
+	wg.Wait()
+	return results
+}
@@ -0,0 +1,95 @@
+package release
+
+import (
+	"fmt"
+
+	"github.com/wahlandcase/attuned.prmanager/internal/github"
+	"github.com/wahlandcase/attuned.prmanager/internal/models"
+)
+
+// MergeEntries lists the open PRs of a repo as merge candidates, in flow order
+func MergeEntries(repo models.RepoInfo, status models.RepoPrStatus) []models.MergePrEntry {
+	var entries []models.MergePrEntry
+	for _, rp := range status.PRs {
+		if rp.PR == nil {
+			continue
+		}
+		entries = append(entries, models.MergePrEntry{
+			Repo:           repo,
+			PrNumber:       rp.PR.Number,
+			PrTitle:        rp.PR.Title,
+			URL:            rp.PR.URL,
+			PrType:         rp.PrType,
+			Mergeable:      rp.PR.Mergeable,
+			ReviewDecision: rp.PR.ReviewDecision,
+			ChecksState:    rp.PR.ChecksState,
+			AutoMerge:      rp.PR.AutoMerge,
+		})
+	}
+	return entries
+}
+
+// OpenMergeEntries fetches open release PRs for all repos in batched queries and lists
+// them as merge candidates in discovery order
+func OpenMergeEntries(client github.Client, repos []models.RepoInfo) ([]models.MergePrEntry, error) {
+	statuses, err := client.ListOpenReleasePRs(repos)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []models.MergePrEntry
+	for _, r := range repos {
+		if status, ok := statuses[r.Path]; ok {
+			entries = append(entries, MergeEntries(r, status)...)
+		}
+	}
+	return entries, nil
+}
+
+// MergePR merges one release PR. Failures are reported in the result rather than as an error.
+func MergePR(client github.Client, entry models.MergePrEntry) models.MergeResult {
+	return merge(entry, client.MergePR)
+}
+
+// EnableAutoMerge has GitHub merge one release PR once its checks and reviews pass.
+// Failures are reported in the result rather than as an error.
+func EnableAutoMerge(client github.Client, entry models.MergePrEntry) models.MergeResult {
+	result := merge(entry, client.EnableAutoMerge)
+	result.AutoMerge = result.Success
+	return result
+}
+
+// merge renders the repo's merge settings for a PR and passes them to mergeFn
+func merge(entry models.MergePrEntry, mergeFn func(repoPath string, prNumber uint64, opts models.MergeOptions) error) models.MergeResult {
+	result := models.MergeResult{
+		RepoName: entry.Repo.DisplayName,
+		PrNumber: entry.PrNumber,
+		PrTitle:  entry.PrTitle,
+		PrType:   entry.PrType,
+		URL:      entry.URL,
+	}
+
+	fail := func(err error) models.MergeResult {
+		errStr := err.Error()
+		result.Error = &errStr
+		return result
+	}
+
+	opts, err := entry.MergeMethod().Options(models.MergeData{
+		Title:  entry.PrTitle,
+		Number: entry.PrNumber,
+		Repo:   entry.Repo.DisplayName,
+		Head:   entry.PrType.HeadBranch(entry.Repo.MainBranch),
+		Base:   entry.PrType.BaseBranch(entry.Repo.MainBranch),
+	})
+	if err != nil {
+		return fail(fmt.Errorf("merge commit template: %w", err))
+	}
+
+	if err := mergeFn(entry.Repo.Path, entry.PrNumber, opts); err != nil {
+		return fail(err)
+	}
+
+	result.Success = true
+	return result
+}
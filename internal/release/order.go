@@ -0,0 +1,115 @@
+package release
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/wahlandcase/attuned.prmanager/internal/github"
+	"github.com/wahlandcase/attuned.prmanager/internal/models"
+)
+
+// MergeDependencies returns, for each entry, the indices of the entries that must merge
+// before it: PRs for the same promotion in the repos listed in its repo's merge_after.
+// Repos that aren't among the entries impose no constraint.
+func MergeDependencies(entries []models.MergePrEntry) [][]int {
+	deps := make([][]int, len(entries))
+	for i, e := range entries {
+		for _, name := range e.Repo.Settings.MergeAfter {
+			for j, up := range entries {
+				if j != i && up.Repo.MatchesName(name) &&
+					up.PrType.Head == e.PrType.Head && up.PrType.Base == e.PrType.Base {
+					deps[i] = append(deps[i], j)
+				}
+			}
+		}
+	}
+	return deps
+}
+
+// MergeOrder returns the entry indices ordered so every PR comes after its dependencies,
+// otherwise keeping the given order. Returns an error if merge_after forms a cycle.
+func MergeOrder(entries []models.MergePrEntry) ([]int, error) {
+	deps := MergeDependencies(entries)
+	done := make([]bool, len(entries))
+	var order []int
+	for len(order) < len(entries) {
+		progressed := false
+		for i := range entries {
+			if done[i] {
+				continue
+			}
+			ready := true
+			for _, d := range deps[i] {
+				if !done[d] {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				done[i] = true
+				order = append(order, i)
+				progressed = true
+				break // Restart so earlier entries unblocked by this one keep their place
+			}
+		}
+		if !progressed {
+			var cycle []string
+			for i, e := range entries {
+				if !done[i] {
+					cycle = append(cycle, e.Repo.DisplayName)
+				}
+			}
+			return nil, fmt.Errorf("merge_after forms a cycle between %s", strings.Join(cycle, ", "))
+		}
+	}
+	return order, nil
+}
+
+// deployClockSkew allows for runs whose timestamps are slightly behind our clock
+const deployClockSkew = 2 * time.Minute
+
+// DeployStatus summarizes the latest run of a deploy workflow on a branch started after a merge
+func DeployStatus(runs []models.WorkflowRun, workflow, branch string, mergedAt time.Time) (CheckState, string) {
+	var latest *models.WorkflowRun
+	for i, run := range runs {
+		if run.WorkflowName != workflow || run.HeadBranch != branch || run.CreatedAt.Before(mergedAt.Add(-deployClockSkew)) {
+			continue
+		}
+		if latest == nil || run.CreatedAt.After(latest.CreatedAt) {
+			latest = &runs[i]
+		}
+	}
+	switch {
+	case latest == nil:
+		return ChecksNone, "waiting for " + workflow + " to start"
+	case latest.Status != "completed":
+		return ChecksRunning, workflow + " running"
+	case latest.Conclusion == "success":
+		return ChecksPassed, ""
+	}
+	return ChecksFailed, fmt.Sprintf("%s %s", workflow, latest.Conclusion)
+}
+
+// WaitForDeploy polls until the repo's deploy workflow succeeds on branch after a merge,
+// returning an error if it fails or doesn't finish within timeout
+func WaitForDeploy(client github.Client, repo models.RepoInfo, branch string, mergedAt time.Time, timeout, interval time.Duration) error {
+	workflow := repo.Settings.DeployWorkflow
+	deadline := mergedAt.Add(timeout)
+	for {
+		runs, err := client.ListWorkflowRuns(repo.Path, 20)
+		if err == nil {
+			state, detail := DeployStatus(runs, workflow, branch, mergedAt)
+			switch state {
+			case ChecksPassed:
+				return nil
+			case ChecksFailed:
+				return fmt.Errorf("%s deploy failed: %s", repo.DisplayName, detail)
+			}
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("%s deploy didn't finish within %s", repo.DisplayName, timeout)
+		}
+		time.Sleep(interval)
+	}
+}
@@ -0,0 +1,29 @@
+package release
+
+import (
+	"github.com/wahlandcase/attuned.prmanager/internal/config"
+	"github.com/wahlandcase/attuned.prmanager/internal/git"
+	"github.com/wahlandcase/attuned.prmanager/internal/models"
+)
+
+// FindRepos discovers all repos in the configured groups with per-repo config applied
+func FindRepos(cfg *config.Config) ([]models.RepoInfo, error) {
+	return git.FindAttunedRepos(cfg.AttunedPath(), cfg.DiscoveryGroups(), cfg.ResolveRepo)
+}
+
+// FilterGroups keeps only repos in the given groups (all repos if groups is empty)
+func FilterGroups(repos []models.RepoInfo, groups []string) []models.RepoInfo {
+	if len(groups) == 0 {
+		return repos
+	}
+	var filtered []models.RepoInfo
+	for _, r := range repos {
+		for _, g := range groups {
+			if r.Group == g {
+				filtered = append(filtered, r)
+				break
+			}
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,152 @@
+package release
+
+import (
+	"encoding/json"
+	"regexp"
+
+	"github.com/wahlandcase/attuned.prmanager/internal/git"
+	"github.com/wahlandcase/attuned.prmanager/internal/github"
+	"github.com/wahlandcase/attuned.prmanager/internal/models"
+)
+
+// StatusOptions controls how Status gathers release drift
+type StatusOptions struct {
+	// TicketRegex is the global ticket pattern (repos may override it)
+	TicketRegex *regexp.Regexp
+	// NoFetch skips fetching from origin and reports on the local remote-tracking refs
+	NoFetch bool
+	// Progress is called before each repo is read (optional)
+	Progress func(repo string)
+}
+
+// RepoStatus is the release drift of one repo
+type RepoStatus struct {
+	Repo models.RepoInfo
+	// Promotions has one entry per promotion in the repo's flow, in flow order
+	Promotions []PromotionStatus
+	// Error is set when the repo couldn't be read at all (e.g., fetch failed)
+	Error string
+}
+
+// PromotionStatus is the drift between two adjacent stages of a repo's flow
+type PromotionStatus struct {
+	PrType models.PrType
+	Head   string
+	Base   string
+	// Commits are on Head but not Base
+	Commits []models.CommitInfo
+	Tickets []string
+	// PR is the open release PR for this promotion (nil if none)
+	PR *models.GhPr
+	// Error is set when the commits couldn't be read (e.g., branch missing)
+	Error string
+}
+
+// Status reports, for every promotion of every repo, the commits waiting to be promoted,
+// their tickets and the open release PR. Open PRs are fetched in one batched query; a
+// failure there is returned as an error, per-repo git failures are reported in the result.
+func Status(client github.Client, repos []models.RepoInfo, opts StatusOptions) ([]RepoStatus, error) {
+	prs, err := client.ListOpenReleasePRs(repos)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]RepoStatus, 0, len(repos))
+	for _, repo := range repos {
+		if opts.Progress != nil {
+			opts.Progress(repo.DisplayName)
+		}
+		statuses = append(statuses, repoStatus(repo, prs[repo.Path], opts))
+	}
+	return statuses, nil
+}
+
+func repoStatus(repo models.RepoInfo, prs models.RepoPrStatus, opts StatusOptions) RepoStatus {
+	status := RepoStatus{Repo: repo}
+
+	if !opts.NoFetch {
+		if err := git.FetchBranches(repo.Path, nil); err != nil {
+			status.Error = err.Error()
+			return status
+		}
+	}
+
+	ticketRegex := repo.Settings.TicketRegexOr(opts.TicketRegex)
+	for _, prType := range repo.Flow.PrTypes() {
+		p := PromotionStatus{
+			PrType: prType,
+			Head:   prType.HeadBranch(repo.MainBranch),
+			Base:   prType.BaseBranch(repo.MainBranch),
+		}
+		for _, r := range prs.PRs {
+			if r.PrType.Head == prType.Head && r.PrType.Base == prType.Base {
+				p.PR = r.PR
+			}
+		}
+
+		commits, err := git.GetCommitsBetween(repo.Path, p.Base, p.Head, ticketRegex)
+		if err != nil {
+			p.Error = err.Error()
+		} else {
+			p.Commits = commits
+			p.Tickets = git.GetAllTickets(commits)
+		}
+		status.Promotions = append(status.Promotions, p)
+	}
+	return status
+}
+
+// MarshalJSON encodes the status for headless output (e.g., attpr status --format json)
+func (s RepoStatus) MarshalJSON() ([]byte, error) {
+	promotions := s.Promotions
+	if promotions == nil {
+		promotions = []PromotionStatus{}
+	}
+	return json.Marshal(struct {
+		Repo       string            `json:"repo"`
+		Path       string            `json:"path"`
+		Group      string            `json:"group,omitempty"`
+		Error      string            `json:"error,omitempty"`
+		Promotions []PromotionStatus `json:"promotions"`
+	}{
+		Repo:       s.Repo.DisplayName,
+		Path:       s.Repo.Path,
+		Group:      s.Repo.Group,
+		Error:      s.Error,
+		Promotions: promotions,
+	})
+}
+
+// MarshalJSON encodes the promotion with commit counts rather than full commits
+func (p PromotionStatus) MarshalJSON() ([]byte, error) {
+	tickets := p.Tickets
+	if tickets == nil {
+		tickets = []string{}
+	}
+	type pr struct {
+		Number uint64 `json:"number"`
+		URL    string `json:"url"`
+		Title  string `json:"title"`
+	}
+	var openPR *pr
+	if p.PR != nil {
+		openPR = &pr{Number: p.PR.Number, URL: p.PR.URL, Title: p.PR.Title}
+	}
+	return json.Marshal(struct {
+		Type    string   `json:"type"`
+		Head    string   `json:"head"`
+		Base    string   `json:"base"`
+		Commits int      `json:"commits"`
+		Tickets []string `json:"tickets"`
+		PR      *pr      `json:"pr"`
+		Error   string   `json:"error,omitempty"`
+	}{
+		Type:    p.PrType.Slug(),
+		Head:    p.Head,
+		Base:    p.Base,
+		Commits: len(p.Commits),
+		Tickets: tickets,
+		PR:      openPR,
+		Error:   p.Error,
+	})
+}
@@ -0,0 +1,138 @@
+// Package tracker formats ticket links and closing keywords for the supported ticket trackers
+package tracker
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/wahlandcase/attuned.prmanager/internal/models"
+)
+
+// Tracker kinds selectable via tickets.tracker
+const (
+	KindLinear = "linear" // Linear issues (magic words close them when the PR merges)
+	KindJira   = "jira"   // Jira issues on a Jira Cloud or Server site
+	KindGitHub = "github" // GitHub Issues (closing keywords close them when the PR merges)
+)
+
+// Kinds lists the tracker kinds
+var Kinds = []string{KindLinear, KindJira, KindGitHub}
+
+// Default closing keywords per tracker. Jira doesn't act on keywords in PR bodies, so its
+// tickets are only linked unless one is configured.
+const (
+	defaultLinearKeyword = "Closes"
+	defaultGitHubKeyword = "Closes"
+)
+
+// Linear links tickets to linear.app/<org>
+type Linear struct {
+	Org     string
+	Keyword string
+}
+
+// URL returns the ticket's Linear URL
+func (l Linear) URL(ticket string) string {
+	return fmt.Sprintf("https://linear.app/%s/issue/%s", l.Org, strings.ToLower(ticket))
+}
+
+// Reference returns the ticket as a markdown link after the closing keyword
+func (l Linear) Reference(ticket string) string {
+	return withKeyword(l.Keyword, fmt.Sprintf("[%s](%s)", ticket, l.URL(ticket)))
+}
+
+// Jira links tickets to <BaseURL>/browse/<ticket>
+type Jira struct {
+	BaseURL string
+	Keyword string
+}
+
+// URL returns the ticket's Jira URL
+func (j Jira) URL(ticket string) string {
+	return strings.TrimRight(j.BaseURL, "/") + "/browse/" + strings.ToUpper(ticket)
+}
+
+// Reference returns the ticket as a markdown link, after the closing keyword if one is set
+func (j Jira) Reference(ticket string) string {
+	return withKeyword(j.Keyword, fmt.Sprintf("[%s](%s)", ticket, j.URL(ticket)))
+}
+
+// GitHubIssues references issues by number (e.g., a ticket "#42" or "GH-42"), in the PR's own
+// repo or in Repo ("owner/name") when set
+type GitHubIssues struct {
+	Repo    string
+	Keyword string
+}
+
+// URL returns the issue's URL ("" without Repo, since GitHub links "#42" itself)
+func (g GitHubIssues) URL(ticket string) string {
+	if g.Repo == "" {
+		return ""
+	}
+	return fmt.Sprintf("https://github.com/%s/issues/%s", g.Repo, issueNumber(ticket))
+}
+
+// Reference returns "#42" (or "owner/name#42") after the closing keyword
+func (g GitHubIssues) Reference(ticket string) string {
+	return withKeyword(g.Keyword, g.Repo+"#"+issueNumber(ticket))
+}
+
+// issueNumber returns the trailing digits of a ticket ID (the ID itself if it has none)
+func issueNumber(ticket string) string {
+	i := strings.LastIndexFunc(ticket, func(r rune) bool { return !unicode.IsDigit(r) })
+	if i == len(ticket)-1 {
+		return ticket
+	}
+	return ticket[i+1:]
+}
+
+func withKeyword(keyword, ref string) string {
+	if keyword == "" {
+		return ref
+	}
+	return keyword + " " + ref
+}
+
+// Options configures a tracker built by New
+type Options struct {
+	// Kind is "linear" (default), "jira" or "github"
+	Kind string
+	// LinearOrg is the Linear organization slug
+	LinearOrg string
+	// JiraURL is the Jira site (e.g., "https://my-org.atlassian.net")
+	JiraURL string
+	// GitHubRepo is the "owner/name" repo issues live in ("" = the PR's repo)
+	GitHubRepo string
+	// Keyword overrides the closing keyword ("none" writes no keyword)
+	Keyword string
+}
+
+// New builds a tracker from its options
+func New(opts Options) (models.TicketTracker, error) {
+	keyword := func(fallback string) string {
+		switch opts.Keyword {
+		case "":
+			return fallback
+		case "none":
+			return ""
+		}
+		return opts.Keyword
+	}
+
+	switch opts.Kind {
+	case "", KindLinear:
+		return Linear{Org: opts.LinearOrg, Keyword: keyword(defaultLinearKeyword)}, nil
+	case KindJira:
+		if !strings.HasPrefix(opts.JiraURL, "https://") && !strings.HasPrefix(opts.JiraURL, "http://") {
+			return nil, fmt.Errorf("jira_url must be an http(s) URL for the jira tracker, got %q", opts.JiraURL)
+		}
+		return Jira{BaseURL: opts.JiraURL, Keyword: keyword("")}, nil
+	case KindGitHub:
+		if opts.GitHubRepo != "" && strings.Count(opts.GitHubRepo, "/") != 1 {
+			return nil, fmt.Errorf("github_repo must be \"owner/name\", got %q", opts.GitHubRepo)
+		}
+		return GitHubIssues{Repo: opts.GitHubRepo, Keyword: keyword(defaultGitHubKeyword)}, nil
+	}
+	return nil, fmt.Errorf("tracker must be one of %s, got %q", strings.Join(Kinds, ", "), opts.Kind)
+}
@@ -0,0 +1,129 @@
+package tracker
+
+import (
+	"testing"
+
+	"github.com/wahlandcase/attuned.prmanager/internal/github"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    Options
+		ticket  string
+		line    string // PR body line for the ticket
+		url     string
+		wantErr string
+	}{
+		{
+			name:   "linear by default",
+			opts:   Options{LinearOrg: "attuned"},
+			ticket: "ATT-12",
+			line:   "### - Closes [ATT-12](https://linear.app/attuned/issue/att-12)",
+			url:    "https://linear.app/attuned/issue/att-12",
+		},
+		{
+			name:   "linear with keyword",
+			opts:   Options{Kind: KindLinear, LinearOrg: "attuned", Keyword: "Fixes"},
+			ticket: "ATT-12",
+			line:   "### - Fixes [ATT-12](https://linear.app/attuned/issue/att-12)",
+			url:    "https://linear.app/attuned/issue/att-12",
+		},
+		{
+			name:   "linear without keyword",
+			opts:   Options{Kind: KindLinear, LinearOrg: "attuned", Keyword: "none"},
+			ticket: "ATT-12",
+			line:   "### - [ATT-12](https://linear.app/attuned/issue/att-12)",
+			url:    "https://linear.app/attuned/issue/att-12",
+		},
+		{
+			name:   "jira links without a keyword",
+			opts:   Options{Kind: KindJira, JiraURL: "https://acme.atlassian.net/"},
+			ticket: "ops-7",
+			line:   "### - [ops-7](https://acme.atlassian.net/browse/OPS-7)",
+			url:    "https://acme.atlassian.net/browse/OPS-7",
+		},
+		{
+			name:   "jira with keyword",
+			opts:   Options{Kind: KindJira, JiraURL: "http://jira.internal", Keyword: "Resolves"},
+			ticket: "OPS-7",
+			line:   "### - Resolves [OPS-7](http://jira.internal/browse/OPS-7)",
+			url:    "http://jira.internal/browse/OPS-7",
+		},
+		{
+			name:    "jira without url",
+			opts:    Options{Kind: KindJira},
+			wantErr: `jira_url must be an http(s) URL for the jira tracker, got ""`,
+		},
+		{
+			name:    "jira url without scheme",
+			opts:    Options{Kind: KindJira, JiraURL: "acme.atlassian.net"},
+			wantErr: `jira_url must be an http(s) URL for the jira tracker, got "acme.atlassian.net"`,
+		},
+		{
+			name:   "github issues in the PR's repo",
+			opts:   Options{Kind: KindGitHub},
+			ticket: "#42",
+			line:   "### - Closes #42",
+			url:    "",
+		},
+		{
+			name:   "github issues in another repo",
+			opts:   Options{Kind: KindGitHub, GitHubRepo: "acme/issues"},
+			ticket: "GH-42",
+			line:   "### - Closes acme/issues#42",
+			url:    "https://github.com/acme/issues/issues/42",
+		},
+		{
+			name:    "github repo without owner",
+			opts:    Options{Kind: KindGitHub, GitHubRepo: "issues"},
+			wantErr: `github_repo must be "owner/name", got "issues"`,
+		},
+		{
+			name:    "github repo with extra segments",
+			opts:    Options{Kind: KindGitHub, GitHubRepo: "acme/issues/extra"},
+			wantErr: `github_repo must be "owner/name", got "acme/issues/extra"`,
+		},
+		{
+			name:    "unknown kind",
+			opts:    Options{Kind: "trello"},
+			wantErr: `tracker must be one of linear, jira, github, got "trello"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr, err := New(tt.opts)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("New error = %v, want %s", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("New: %v", err)
+			}
+
+			if got, want := github.GeneratePRBody([]string{tt.ticket}, tr), "# Tickets\n\n"+tt.line; got != want {
+				t.Errorf("PR body = %q, want %q", got, want)
+			}
+			if got := tr.URL(tt.ticket); got != tt.url {
+				t.Errorf("URL = %q, want %q", got, tt.url)
+			}
+		})
+	}
+}
+
+func TestIssueNumber(t *testing.T) {
+	tests := map[string]string{
+		"#42":   "42",
+		"GH-42": "42",
+		"42":    "42",
+		"GH":    "GH",
+	}
+	for ticket, want := range tests {
+		if got := issueNumber(ticket); got != want {
+			t.Errorf("issueNumber(%q) = %q, want %q", ticket, got, want)
+		}
+	}
+}
@@ -177,7 +177,7 @@ func MenuInfoPanel(index int) (title string, lines []string) {
 			"",
 			"  • Detects dev/staging/main branches",
 			"  • Shows commits to be merged",
-			"  • Extracts Linear tickets (ATT-XXX)",
+			"  • Extracts tickets (Linear, Jira, GitHub)",
 			"  • Creates or updates existing PR",
 		}
 	case 1: // Batch Mode
@@ -192,7 +192,7 @@ func MenuInfoPanel(index int) (title string, lines []string) {
 			"",
 			"  • Scans ~/Programming/attuned",
 			"  • Select repos with checkboxes",
-			"  • Extracts Linear tickets (ATT-XXX)",
+			"  • Extracts tickets (Linear, Jira, GitHub)",
 			"  • Shows summary of results",
 		}
 	case 2: // View Open PRs
@@ -243,8 +243,20 @@ func MenuInfoPanel(index int) (title string, lines []string) {
 
 // TwoColumns renders two columns side by side
 func TwoColumns(left, right string, gap int) string {
+	return Columns(gap, left, right)
+}
+
+// Columns renders any number of columns side by side
+func Columns(gap int, columns ...string) string {
 	gapStr := strings.Repeat(" ", gap)
-	return lipgloss.JoinHorizontal(lipgloss.Top, left, gapStr, right)
+	var parts []string
+	for i, col := range columns {
+		if i > 0 {
+			parts = append(parts, gapStr)
+		}
+		parts = append(parts, col)
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, parts...)
 }
 
 // UnifiedPanel creates two columns with a vertical separator (no border - outer border is in View)
@@ -410,6 +422,39 @@ func PRListItem(repoName string, prNumber uint64, selected bool, highlighted boo
 	)
 }
 
+// MergeBadges renders compact CI, review, conflict and auto-merge badges for an open PR
+// (states as reported by GitHub; empty means none required/reported)
+func MergeBadges(mergeable, reviewDecision, checksState string, autoMerge bool) string {
+	badge := func(label, icon string, color lipgloss.Color) string {
+		return lipgloss.NewStyle().Foreground(color).Render(label + icon)
+	}
+
+	var badges []string
+	switch checksState {
+	case "SUCCESS":
+		badges = append(badges, badge("ci", "✓", ColorGreen))
+	case "FAILURE", "ERROR":
+		badges = append(badges, badge("ci", "✗", ColorRed))
+	case "PENDING", "EXPECTED":
+		badges = append(badges, badge("ci", "●", ColorYellow))
+	}
+	switch reviewDecision {
+	case "APPROVED":
+		badges = append(badges, badge("rv", "✓", ColorGreen))
+	case "CHANGES_REQUESTED":
+		badges = append(badges, badge("rv", "✗", ColorRed))
+	case "REVIEW_REQUIRED":
+		badges = append(badges, badge("rv", "●", ColorYellow))
+	}
+	if mergeable == "CONFLICTING" {
+		badges = append(badges, badge("", "⚠ conflict", ColorRed))
+	}
+	if autoMerge {
+		badges = append(badges, badge("", "⏲ auto", ColorCyan))
+	}
+	return strings.Join(badges, " ")
+}
+
 // ParentHeader renders a parent repo header for nested repos
 func ParentHeader(name string) string {
 	style := lipgloss.NewStyle().Foreground(ColorYellow).Bold(true)
@@ -420,6 +465,12 @@ func ParentHeader(name string) string {
 	)
 }
 
+// GroupHeader renders a repo group label for lists that mix several groups
+func GroupHeader(name string, color lipgloss.Color) string {
+	style := lipgloss.NewStyle().Foreground(color).Bold(true)
+	return "  " + style.Render(GroupIcon(name)+" "+name)
+}
+
 // MenuRow renders a menu row with optional highlight background
 // width should be the inner width of the panel (excluding border)
 func MenuRow(icon, title, desc string, color lipgloss.Color, selected bool, width int) []string {
@@ -1,6 +1,10 @@
 package ui
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
 
 // Note: Warp terminal fix is in internal/termfix package, imported first in main.go
 
@@ -20,13 +24,71 @@ var (
 
 func BranchColor(branch string) lipgloss.Color {
 	switch branch {
-	case "dev":
+	case "dev", "develop":
 		return ColorGreen
-	case "staging":
+	case "staging", "qa", "release":
 		return ColorYellow
-	case "main", "master":
+	case "main", "master", "production":
 		return ColorRed
 	default:
 		return ColorWhite
 	}
 }
+
+// StageColor returns the color for stage i of an n-stage release flow:
+// green for the first stage, red for the last, yellow/orange in between
+func StageColor(i, n int) lipgloss.Color {
+	switch {
+	case i <= 0:
+		return ColorGreen
+	case i >= n-1:
+		return ColorRed
+	case i%2 == 1:
+		return ColorYellow
+	default:
+		return ColorOrange
+	}
+}
+
+// StageDot returns the colored emoji dot for stage i of an n-stage release flow
+func StageDot(i, n int) string {
+	switch StageColor(i, n) {
+	case ColorGreen:
+		return "🟢"
+	case ColorRed:
+		return "🔴"
+	case ColorOrange:
+		return "🟠"
+	default:
+		return "🟡"
+	}
+}
+
+// groupColors cycle across repo groups (frontend and backend keep their original cyan/magenta)
+var groupColors = []lipgloss.Color{ColorCyan, ColorMagenta, ColorBlue, ColorOrange, ColorLightGreen, ColorPurple}
+
+// GroupColor returns the color for the i-th repo group
+func GroupColor(i int) lipgloss.Color {
+	if i < 0 {
+		return ColorWhite
+	}
+	return groupColors[i%len(groupColors)]
+}
+
+// GroupIcon returns the emoji shown next to a repo group's name
+func GroupIcon(name string) string {
+	switch strings.ToLower(name) {
+	case "frontend", "web":
+		return "🖥️ "
+	case "backend", "services":
+		return "⚙️ "
+	case "mobile", "apps":
+		return "📱"
+	case "infra", "infrastructure", "ops":
+		return "🏗️ "
+	case "libs", "lib", "packages":
+		return "📚"
+	default:
+		return "📁"
+	}
+}